@@ -1,5 +1,277 @@
 package main
 
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/low4ey/sniper/internal/config"
+	envinit "github.com/low4ey/sniper/internal/init"
+	"github.com/low4ey/sniper/package/httpclient"
+	"github.com/low4ey/sniper/package/listener"
+	"github.com/low4ey/sniper/package/models"
+	"github.com/low4ey/sniper/package/monitor"
+	"github.com/low4ey/sniper/package/pipeline"
+	"github.com/low4ey/sniper/package/price"
+	"github.com/low4ey/sniper/package/pumpfun"
+	"github.com/low4ey/sniper/package/raydium"
+	"github.com/low4ey/sniper/package/tracker/db"
+	transactions "github.com/low4ey/sniper/package/transaction.go"
+)
+
+// drainTimeout bounds how long buys and sells already started may continue
+// after a shutdown signal, so their bookkeeping is not lost.
+const drainTimeout = 30 * time.Second
+
+// statsInterval is how often the pipeline counters are logged.
+const statsInterval = time.Minute
+
 func main() {
+	env, err := envinit.Load()
+	if err != nil {
+		log.Printf("🚫 Invalid environment: %v", err)
+		os.Exit(1)
+	}
+
+	configPath := flag.String("config", os.Getenv("SNIPER_CONFIG"), "path to a YAML config file overriding the built-in defaults")
+	flag.Parse()
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		log.Printf("🚫 Invalid configuration: %v", err)
+		os.Exit(1)
+	}
+	config.Set(cfg)
+
+	httpClient := transactions.NewHTTPClient(env)
+	client, err := transactions.NewClient(env, httpClient.Client)
+	if err != nil {
+		log.Printf("🚫 Invalid wallet: %v", err)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	work := drainContext(ctx, drainTimeout)
+
+	if *configPath != "" {
+		go config.Watch(ctx, *configPath, time.Second)
+	}
+
+	var inFlight sync.WaitGroup
+	sellMonitor := monitor.New(client,
+		price.NewDexscreener(env.DexHTTPSTokenPairs, httpClient.Client, 2*time.Second),
+		price.NewJupiter(env.JupHTTPSPriceURI, httpClient.Client),
+	)
+	inFlight.Add(1)
+	go func() {
+		defer inFlight.Done()
+		sellMonitor.Run(ctx, work)
+	}()
+
+	if err := listen(ctx, work, env, client); err != nil && !errors.Is(err, context.Canceled) {
+		log.Printf("⛔ Listener stopped: %v", err)
+	}
+
+	log.Printf("Shutting down, waiting for in-flight transactions to finish...")
+	inFlight.Wait()
+	if err := db.Close(); err != nil {
+		log.Printf("⛔ Could not close database: %v", err)
+	}
+	logHTTPMetrics(httpClient)
+	log.Printf("Sniper stopped.")
+}
+
+// drainContext returns a context that is not cancelled with ctx but expires
+// timeout after it.
+func drainContext(ctx context.Context, timeout time.Duration) context.Context {
+	work, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	context.AfterFunc(ctx, func() { time.AfterFunc(timeout, cancel) })
+	return work
+}
+
+// listen queues every pool creation reported by the listeners into a
+// pipeline of TxConfig.ConcurrentTransactions workers until ctx is cancelled,
+// then waits for the buys in progress, which run on work. Every configured
+// pool program is listened to and, unless pump.fun tokens are skipped,
+// pump.fun migrations as well.
+func listen(ctx, work context.Context, env *envinit.EnvConfig, client *transactions.Client) error {
+	cfg := config.Get()
+	pools, err := poolListeners(env.HeliusWSSURI, cfg)
+	if err != nil {
+		return err
+	}
+	sources := make([]pipeline.Source, len(pools))
+	for i, pool := range pools {
+		sources[i] = pool.source
+		sources[i].Signatures = pool.listener.Listen(ctx)
+	}
+
+	var buys *pipeline.Pipeline
+	buys = pipeline.New(func(ctx context.Context, signature string) {
+		log.Printf("🔎 New liquidity pool found: https://solscan.io/tx/%s", signature)
+		processSignature(ctx, client, buys, signature)
+	}, pipeline.Options{
+		Workers:   cfg.Tx.ConcurrentTransactions,
+		QueueSize: cfg.Tx.QueueSize,
+		MaxAge:    time.Duration(cfg.Tx.QueueMaxAge) * time.Millisecond,
+	})
+	go func() {
+		ticker := time.NewTicker(statsInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				logPipelineStats(buys.Stats())
+			}
+		}
+	}()
+	buys.Run(ctx, work, sources...)
+
+	logPipelineStats(buys.Stats())
+	return ctx.Err()
+}
+
+// logPipelineStats reports the pipeline queue depth and counters.
+func logPipelineStats(stats pipeline.Stats) {
+	log.Printf("📊 Pipeline: %d waiting, %d in flight, %d scheduled, %d queued, %d processed, %d duplicates, %d dropped, %d expired",
+		stats.Depth, stats.InFlight, stats.Scheduled, stats.Accepted, stats.Processed, stats.Duplicates, stats.Dropped, stats.Expired)
+}
+
+// poolListener is a listener together with the pipeline source it feeds.
+type poolListener struct {
+	listener *listener.Listener
+	source   pipeline.Source
+}
+
+// poolListeners builds a listener for every pool program of cfg and, unless
+// pump.fun tokens are skipped, one for pump.fun migrations, whose source is
+// prioritized when they are preferred.
+func poolListeners(wssURL string, cfg *config.Config) ([]poolListener, error) {
+	var pools []poolListener
+	for programID, poolType := range transactions.PoolPrograms(cfg.LiquidityPool) {
+		programListener, err := listener.New(wssURL, programID)
+		if err != nil {
+			return nil, err
+		}
+		poolType := poolType
+		programListener.Match = func(logs []string) bool { return raydium.IsCreationLog(poolType, logs) }
+		pools = append(pools, poolListener{programListener, pipeline.Source{Name: string(poolType)}})
+	}
+	if policy := cfg.RugCheck.PumpFunPolicy; policy != pumpfun.PolicySkip {
+		migrationListener, err := listener.New(wssURL, pumpfun.MigrationAccount)
+		if err != nil {
+			return nil, err
+		}
+		pools = append(pools, poolListener{migrationListener, pipeline.Source{Name: "pump.fun", Priority: policy == pumpfun.PolicyPrefer}})
+	}
+	return pools, nil
+}
+
+// processSignature runs a pool creation through detection and rug check,
+// then schedules its buy on buys for when the pool opens, so waiting for the
+// open time does not hold a worker.
+func processSignature(ctx context.Context, client *transactions.Client, buys *pipeline.Pipeline, signature string) {
+	mints, err := client.FetchTransactionDetails(ctx, signature)
+	if err != nil {
+		log.Printf("⛔ Could not fetch transaction details for %s: %v", signature, err)
+		return
+	}
+
+	if mints.PumpFun && config.Get().RugCheck.PumpFunPolicy == pumpfun.PolicySkip {
+		log.Printf("🚫 %s was launched on pump.fun, skipping", mints.TokenMint)
+		return
+	}
+
+	ok, err := client.GetRugCheckConfirmed(ctx, mints.TokenMint)
+	if err != nil {
+		log.Printf("⛔ Rug check failed for %s: %v", mints.TokenMint, err)
+		return
+	}
+	if !ok {
+		log.Printf("🚫 Rug check not passed for %s, skipping", mints.TokenMint)
+		return
+	}
+
+	wait, err := buyDelay(mints.Pool, time.Now(), config.Get().Tx)
+	if err != nil {
+		log.Printf("🚫 %v, skipping %s", err, mints.TokenMint)
+		return
+	}
+	if mints.Pool.OpensIn(time.Now()) > 0 {
+		log.Printf("⏳ Pool %s opens at %s, buying in %v", mints.Pool.ID, mints.Pool.OpenTime.Format(time.RFC3339), wait.Round(time.Second))
+	}
+	buys.Schedule(wait, signature, func(ctx context.Context) { buyAndSave(ctx, client, mints) })
+}
+
+// buyAndSave buys the token of mints and records the holding.
+func buyAndSave(ctx context.Context, client *transactions.Client, mints *models.MintsDataReponse) {
+	txid, err := buy(ctx, client, mints)
+	if err != nil {
+		log.Printf("⛔ Swap failed for %s: %v", mints.TokenMint, err)
+		return
+	}
+	if txid == transactions.SimulatedTx {
+		log.Printf("📝 Paper holding saved for %s", mints.TokenMint)
+		return
+	}
+	log.Printf("🚀 Swap transaction: https://solscan.io/tx/%s", txid)
+
+	saved, err := client.FetchAndSaveSwapDetails(ctx, txid)
+	if err != nil || !saved {
+		log.Printf("⛔ Could not save swap details for %s: %v", txid, err)
+		return
+	}
+	log.Printf("✅ Holding saved for %s", mints.TokenMint)
+}
+
+// buyDelay returns how long to wait before buying from pool: until its open
+// time if that is still ahead, TxConfig.SwapTxInitialDelay otherwise. Pools
+// opening later than TxConfig.MaxOpenTimeWait are not waited for.
+func buyDelay(pool *raydium.Pool, now time.Time, tx config.TxConfig) (time.Duration, error) {
+	opensIn := pool.OpensIn(now)
+	if opensIn == 0 {
+		return time.Duration(tx.SwapTxInitialDelay) * time.Millisecond, nil
+	}
+	if maxWait := time.Duration(tx.MaxOpenTimeWait) * time.Millisecond; opensIn > maxWait {
+		return 0, fmt.Errorf("pool %s opens in %v, more than %v away", pool.ID, opensIn.Round(time.Second), maxWait)
+	}
+	return opensIn, nil
+}
+
+// buy swaps into the new token along SwapConfig.Router. Direct swaps only
+// support AMM v4 pools, buys from other pools go through Jupiter.
+func buy(ctx context.Context, client *transactions.Client, mints *models.MintsDataReponse) (string, error) {
+	if config.Get().Swap.Router == "raydium" {
+		if mints.Pool.Type == raydium.AmmV4 {
+			return client.CreateRaydiumSwapTransaction(ctx, &mints.Pool.PoolKeys, mints.SolMint, mints.TokenMint)
+		}
+		log.Printf("⚠️ No direct swap for %s pools, buying %s through Jupiter", mints.Pool.Type, mints.TokenMint)
+	}
+	return client.CreateSwapTransaction(ctx, mints.SolMint, mints.TokenMint)
+}
 
+// logHTTPMetrics reports the retries and rate limiting seen per API host.
+func logHTTPMetrics(httpClient *httpclient.Client) {
+	metrics := httpClient.Metrics()
+	hosts := make([]string, 0, len(metrics))
+	for host := range metrics {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	for _, host := range hosts {
+		m := metrics[host]
+		log.Printf("📊 %s: %d requests, %d retries, %d rate limited, %d failed", host, m.Requests, m.Retries, m.RateLimited, m.Failures)
+	}
 }
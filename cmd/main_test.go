@@ -0,0 +1,81 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/low4ey/sniper/internal/config"
+	"github.com/low4ey/sniper/package/pumpfun"
+	"github.com/low4ey/sniper/package/raydium"
+)
+
+func TestBuyDelay(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	tx := config.TxConfig{SwapTxInitialDelay: 1000, MaxOpenTimeWait: 60000}
+
+	tests := []struct {
+		name     string
+		openTime time.Time
+		want     time.Duration
+		wantErr  bool
+	}{
+		{name: "open on creation", want: time.Second},
+		{name: "opened in the past", openTime: now.Add(-time.Hour), want: time.Second},
+		{name: "opens soon", openTime: now.Add(20 * time.Second), want: 20 * time.Second},
+		{name: "opens at the longest wait", openTime: now.Add(time.Minute), want: time.Minute},
+		{name: "opens beyond the longest wait", openTime: now.Add(time.Minute + time.Second), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buyDelay(&raydium.Pool{OpenTime: tt.openTime}, now, tx)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPoolListeners(t *testing.T) {
+	tests := []struct {
+		policy   string
+		want     []string
+		priority bool
+	}{
+		{policy: pumpfun.PolicySkip, want: []string{"amm_v4", "cpmm"}},
+		{policy: pumpfun.PolicyAllow, want: []string{"amm_v4", "cpmm", "pump.fun"}},
+		{policy: pumpfun.PolicyPrefer, want: []string{"amm_v4", "cpmm", "pump.fun"}, priority: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			cfg := config.ConfigVal
+			cfg.RugCheck.PumpFunPolicy = tt.policy
+			pools, err := poolListeners("wss://mainnet.helius-rpc.com/?api-key=key", &cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sources := make(map[string]bool)
+			for _, pool := range pools {
+				sources[pool.source.Name] = true
+				wantPriority := pool.source.Name == "pump.fun" && tt.priority
+				if pool.source.Priority != wantPriority {
+					t.Errorf("%s source priority %v, want %v", pool.source.Name, pool.source.Priority, wantPriority)
+				}
+				if pool.source.Name == "pump.fun" && pool.listener.ProgramID.String() != pumpfun.MigrationAccount {
+					t.Errorf("pump.fun listener watches %s, want the migration account", pool.listener.ProgramID)
+				}
+			}
+			if len(pools) != len(tt.want) {
+				t.Fatalf("got %d listeners, want %v", len(pools), tt.want)
+			}
+			for _, name := range tt.want {
+				if !sources[name] {
+					t.Errorf("no %s listener among %v", name, sources)
+				}
+			}
+		})
+	}
+}
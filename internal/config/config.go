@@ -24,88 +24,120 @@ package config
 //  7. Low Amount of LP Providers: Few liquidity providers can destabilize the market if they withdraw.
 
 type LiquidityPoolConfig struct {
-	RadiyumProgramID string
-	WsolPcMint       string
+	RadiyumProgramID     string `yaml:"radiyum_program_id"`      // Raydium AMM v4 program
+	RaydiumCpmmProgramID string `yaml:"raydium_cpmm_program_id"` // Raydium CPMM program (empty = not watched)
+	RaydiumClmmProgramID string `yaml:"raydium_clmm_program_id"` // Raydium CLMM program (empty = not watched); new CLMM pools have no liquidity until a position is opened
+	WsolPcMint           string `yaml:"wsol_pc_mint"`
 }
 
 type TxConfig struct {
-	FetchTxMaxRetries      int // Maximum number of retries for fetching transactions
-	FetchTxInitialDelay    int // Initial delay (in milliseconds) before fetching LP creation transaction details
-	SwapTxInitialDelay     int // Initial delay (in milliseconds) before first buy
-	GetTimeout             int // Timeout (in milliseconds) for API requests
-	ConcurrentTransactions int // Number of simultaneous transactions
-	RetryDelay             int // Delay (in milliseconds) between retries
+	FetchTxMaxRetries      int `yaml:"fetch_tx_max_retries"`    // Maximum number of retries for fetching transactions
+	FetchTxInitialDelay    int `yaml:"fetch_tx_initial_delay"`  // Initial delay (in milliseconds) before fetching LP creation transaction details
+	SwapTxInitialDelay     int `yaml:"swap_tx_initial_delay"`   // Initial delay (in milliseconds) before first buy of a pool that is already open
+	MaxOpenTimeWait        int `yaml:"max_open_time_wait"`      // Longest wait (in milliseconds) for a pool's open time before its buy is skipped
+	GetTimeout             int `yaml:"get_timeout"`             // Timeout (in milliseconds) for API requests
+	ConcurrentTransactions int `yaml:"concurrent_transactions"` // Number of simultaneous transactions
+	QueueSize              int `yaml:"queue_size"`              // New pools waiting for a free transaction slot, further ones are dropped
+	QueueMaxAge            int `yaml:"queue_max_age"`           // Maximum time (in milliseconds) a new pool may wait in the queue (0 = no limit)
+	RetryDelay             int `yaml:"retry_delay"`             // Delay (in milliseconds) between retries
+	HTTPMaxRetries         int `yaml:"http_max_retries"`        // Retries of API requests failing with network errors, 429 or 5xx
+	HTTPMaxRetryDelay      int `yaml:"http_max_retry_delay"`    // Maximum backoff (in milliseconds) between API request retries, starting from retry_delay
+	HeliusRateLimit        int `yaml:"helius_rate_limit"`       // Requests per second to Helius (0 = unlimited)
+	JupiterRateLimit       int `yaml:"jupiter_rate_limit"`      // Requests per second to Jupiter (0 = unlimited)
+	RugCheckRateLimit      int `yaml:"rug_check_rate_limit"`    // Requests per second to rugcheck.xyz (0 = unlimited)
+	DexscreenerRateLimit   int `yaml:"dexscreener_rate_limit"`  // Requests per second to Dexscreener (0 = unlimited)
 }
 
 type SwapConfig struct {
-	VerboseLog                      bool
-	PrioFeeMaxLamports              int    // Maximum priority fee in lamports (e.g., 1000000 = 0.001 SOL)
-	PrioLevel                       string // Priority level (e.g., "veryHigh")
-	Amount                          string // Swap amount (as string to preserve precision, e.g., "10000000" for 0.01 SOL)
-	SlippageBps                     string // Slippage in basis points (e.g., "200" for 2%)
-	DbNameTrackerHoldings           string // Sqlite Database location for tracking holdings
-	TokenNotTradable400ErrorRetries int    // Number of retries if the token is not tradable yet
-	TokenNotTradable400ErrorDelay   int    // Delay (in milliseconds) between retries for tradability check
+	VerboseLog                      bool     `yaml:"verbose_log"`
+	PrioFeeMaxLamports              int      `yaml:"prio_fee_max_lamports"`                // Maximum priority fee in lamports (e.g., 1000000 = 0.001 SOL)
+	PrioLevel                       string   `yaml:"prio_level"`                           // Priority level (e.g., "veryHigh")
+	Amount                          string   `yaml:"amount"`                               // Swap amount (as string to preserve precision, e.g., "10000000" for 0.01 SOL)
+	SlippageBps                     string   `yaml:"slippage_bps"`                         // Slippage in basis points (e.g., "200" for 2%)
+	DbNameTrackerHoldings           string   `yaml:"db_name_tracker_holdings"`             // Sqlite Database location for tracking holdings
+	TokenNotTradable400ErrorRetries int      `yaml:"token_not_tradable_400_error_retries"` // Number of retries if the token is not tradable yet
+	TokenNotTradable400ErrorDelay   int      `yaml:"token_not_tradable_400_error_delay"`   // Delay (in milliseconds) between retries for tradability check
+	MaxPriceImpactBps               int      `yaml:"max_price_impact_bps"`                 // Maximum price impact of a buy quote in basis points (0 disables the check)
+	MaxRouteHops                    int      `yaml:"max_route_hops"`                       // Maximum number of hops in a buy route (0 disables the check)
+	AllowAmms                       []string `yaml:"allow_amms"`                           // AMM labels a buy route may use (empty allows all)
+	DenyAmms                        []string `yaml:"deny_amms"`                            // AMM labels a buy route must not use
+	Router                          string   `yaml:"router"`                               // How buys are built: "jupiter" or "raydium" (direct swap, AMM v4 pools only)
+	ComputeUnitLimit                int      `yaml:"compute_unit_limit"`                   // Compute units requested by directly built swaps; the priority fee is spread over them
 }
 
 type SellConfig struct {
-	PriceSource        string // Price source identifier (e.g., "dex" for Dexscreener)
-	PrioFeeMaxLamports int    // Maximum priority fee in lamports
-	PrioLevel          string // Priority level (e.g., "veryHigh")
-	SlippageBps        string // Slippage in basis points
-	AutoSell           bool   // Automatically trigger stop loss and take profit
-	StopLossPercent    int    // Stop loss percentage
-	TakeProfitPercent  int    // Take profit percentage
-	TrackPublicWallet  string // Public wallet address to track (if any)
+	PriceSource        string   `yaml:"price_source"`          // Price source identifier ("dex" for Dexscreener, "jup" for Jupiter)
+	PriceFallbacks     []string `yaml:"price_fallbacks"`       // Price sources tried after price_source, in order
+	MaxPriceDeviation  int      `yaml:"max_price_deviation"`   // Maximum percentage two sources may disagree by (0 disables the cross-check; when enabled a price only one source has is rejected)
+	MaxPriceAge        int      `yaml:"max_price_age"`         // Maximum age (in milliseconds) of a price quote (0 disables the check)
+	PrioFeeMaxLamports int      `yaml:"prio_fee_max_lamports"` // Maximum priority fee in lamports
+	PrioLevel          string   `yaml:"prio_level"`            // Priority level (e.g., "veryHigh")
+	SlippageBps        string   `yaml:"slippage_bps"`          // Slippage in basis points
+	AutoSell           bool     `yaml:"auto_sell"`             // Automatically trigger stop loss and take profit
+	StopLossPercent    int      `yaml:"stop_loss_percent"`     // Stop loss percentage
+	TakeProfitPercent  int      `yaml:"take_profit_percent"`   // Take profit percentage
+	PriceCheckInterval int      `yaml:"price_check_interval"`  // Interval (in milliseconds) between auto sell price checks
+	TrackPublicWallet  string   `yaml:"track_public_wallet"`   // Public wallet address to track (if any)
 }
 
 type RugCheckConfig struct {
-	VerboseLog     bool
-	SimulationMode bool
+	VerboseLog     bool `yaml:"verbose_log"`
+	SimulationMode bool `yaml:"simulation_mode"` // Record paper trades from real quotes instead of sending transactions
 	// Dangerous
-	AllowMintAuthority   bool // Allow mint authority (should be false)
-	AllowNotInitialized  bool // Allow uninitialized token accounts (should be false)
-	AllowFreezeAuthority bool // Allow freeze authority (should be false)
-	AllowRugged          bool
+	AllowMintAuthority   bool `yaml:"allow_mint_authority"`   // Allow mint authority (should be false)
+	AllowNotInitialized  bool `yaml:"allow_not_initialized"`  // Allow uninitialized token accounts (should be false)
+	AllowFreezeAuthority bool `yaml:"allow_freeze_authority"` // Allow freeze authority (should be false)
+	AllowRugged          bool `yaml:"allow_rugged"`
 	// Critical
-	AllowMutable                bool
-	BlockReturningTokenNames    bool
-	BlockReturningTokenCreators bool
-	BlockSymbols                []string
-	BlockNames                  []string
-	AllowInsiderTopholders      bool // Allow insider accounts among top holders
-	MaxAlowedPctTopholders      int  // Maximum allowed percentage that an individual top holder may have
-	ExcludeLPFromTopholders     bool // Exclude Liquidity Pools from top holders check
+	AllowMutable                bool     `yaml:"allow_mutable"`
+	BlockReturningTokenNames    bool     `yaml:"block_returning_token_names"`
+	BlockReturningTokenCreators bool     `yaml:"block_returning_token_creators"`
+	BlockSymbols                []string `yaml:"block_symbols"`
+	BlockNames                  []string `yaml:"block_names"`
+	AllowInsiderTopholders      bool     `yaml:"allow_insider_topholders"`   // Allow insider accounts among top holders
+	MaxAlowedPctTopholders      int      `yaml:"max_alowed_pct_topholders"`  // Maximum allowed percentage that an individual top holder may have
+	ExcludeLPFromTopholders     bool     `yaml:"exclude_lp_from_topholders"` // Exclude Liquidity Pools from top holders check
 	// Warning
-	MinTotalMarkets         int
-	MinTotalLPProviders     int
-	MinTotalMarketLiquidity int
+	MinTotalMarkets         int `yaml:"min_total_markets"`
+	MinTotalLPProviders     int `yaml:"min_total_lp_providers"`
+	MinTotalMarketLiquidity int `yaml:"min_total_market_liquidity"`
 	// Misc
-	IgnorePumpFun    bool
-	MaxScore         int      // Set to 0 to ignore scoring
-	LegacyNotAllowed []string // List of legacy conditions that are not allowed
+	PumpFunPolicy    string   `yaml:"pump_fun_policy"`    // Tokens launched on pump.fun: "skip", "allow" or "prefer" (also listen for pump.fun migrations and buy them first)
+	MaxScore         int      `yaml:"max_score"`          // Set to 0 to ignore scoring
+	LegacyNotAllowed []string `yaml:"legacy_not_allowed"` // List of legacy conditions that are not allowed
 }
 
 type Config struct {
-	LiquidityPool LiquidityPoolConfig
-	Tx            TxConfig
-	Swap          SwapConfig
-	Sell          SellConfig
-	RugCheck      RugCheckConfig
+	LiquidityPool LiquidityPoolConfig `yaml:"liquidity_pool"`
+	Tx            TxConfig            `yaml:"tx"`
+	Swap          SwapConfig          `yaml:"swap"`
+	Sell          SellConfig          `yaml:"sell"`
+	RugCheck      RugCheckConfig      `yaml:"rug_check"`
 }
 
 var ConfigVal = Config{
 	LiquidityPool: LiquidityPoolConfig{
-		RadiyumProgramID: "675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8",
-		WsolPcMint:       "So11111111111111111111111111111111111111112",
+		RadiyumProgramID:     "675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8",
+		RaydiumCpmmProgramID: "CPMMoo8L3F4NbTegBCKVNunggL7H1ZpdTHKxQB5qKP1C",
+		RaydiumClmmProgramID: "",
+		WsolPcMint:           "So11111111111111111111111111111111111111112",
 	},
 	Tx: TxConfig{
 		FetchTxMaxRetries:      10,
 		FetchTxInitialDelay:    3000,  // 3 seconds
 		SwapTxInitialDelay:     1000,  // 1 second
+		MaxOpenTimeWait:        60000, // 1 minute
 		GetTimeout:             10000, // 10 seconds
 		ConcurrentTransactions: 1,
-		RetryDelay:             500, // 0.5 seconds
+		QueueSize:              50,
+		QueueMaxAge:            30000, // 30 seconds
+		RetryDelay:             500,   // 0.5 seconds
+		HTTPMaxRetries:         3,
+		HTTPMaxRetryDelay:      15000, // 15 seconds
+		HeliusRateLimit:        10,
+		JupiterRateLimit:       10,
+		RugCheckRateLimit:      2,
+		DexscreenerRateLimit:   5,
 	},
 	Swap: SwapConfig{
 		VerboseLog:                      false,
@@ -116,15 +148,23 @@ var ConfigVal = Config{
 		DbNameTrackerHoldings:           "src/tracker/holdings.db",
 		TokenNotTradable400ErrorRetries: 5,
 		TokenNotTradable400ErrorDelay:   2000, // 2 seconds
+		MaxPriceImpactBps:               500,  // 5%
+		MaxRouteHops:                    2,
+		Router:                          "jupiter",
+		ComputeUnitLimit:                200000,
 	},
 	Sell: SellConfig{
 		PriceSource:        "dex",
+		PriceFallbacks:     []string{"jup"},
+		MaxPriceDeviation:  0,       // off, new tokens often have a single price source
+		MaxPriceAge:        10000,   // 10 seconds
 		PrioFeeMaxLamports: 1000000, // 0.001 SOL
 		PrioLevel:          "veryHigh",
 		SlippageBps:        "200", // 2%
 		AutoSell:           true,
 		StopLossPercent:    10,
 		TakeProfitPercent:  100,
+		PriceCheckInterval: 5000, // 5 seconds
 		TrackPublicWallet:  "",
 	},
 	RugCheck: RugCheckConfig{
@@ -149,7 +189,7 @@ var ConfigVal = Config{
 		MinTotalLPProviders:     999,
 		MinTotalMarketLiquidity: 1000000,
 		// Misc
-		IgnorePumpFun: true,
+		PumpFunPolicy: "skip",
 		MaxScore:      1,
 		LegacyNotAllowed: []string{
 			"Low Liquidity",
@@ -0,0 +1,212 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix prefixes the environment variables that override single config
+// fields, e.g. SNIPER_SWAP_SLIPPAGE_BPS=300 or SNIPER_SELL_STOP_LOSS_PERCENT=15.
+// The rest of the name is the upper-cased yaml path of the field.
+const EnvPrefix = "SNIPER_"
+
+var (
+	prioLevels      = []string{"min", "low", "medium", "high", "veryHigh", "unsafeMax"}
+	priceSources    = []string{"dex", "jup"}
+	routers         = []string{"jupiter", "raydium"}
+	pumpFunPolicies = []string{"skip", "allow", "prefer"}
+)
+
+// Load builds a Config from the current ConfigVal defaults, the YAML file at
+// path (skipped when path is empty) and SNIPER_* environment overrides, and
+// validates the result. Keys the file sets that no config field has are
+// rejected, so a misspelled setting cannot silently keep its default.
+func Load(path string) (*Config, error) {
+	cfg := ConfigVal.clone()
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config file: %v", err)
+		}
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("failed to parse config file %s: %v", path, err)
+		}
+	}
+
+	if err := applyEnvOverrides(&cfg, os.LookupEnv); err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// clone returns a copy of c that shares no slices with it.
+func (c Config) clone() Config {
+	c.Swap.AllowAmms = append([]string(nil), c.Swap.AllowAmms...)
+	c.Swap.DenyAmms = append([]string(nil), c.Swap.DenyAmms...)
+	c.Sell.PriceFallbacks = append([]string(nil), c.Sell.PriceFallbacks...)
+	c.RugCheck.BlockSymbols = append([]string(nil), c.RugCheck.BlockSymbols...)
+	c.RugCheck.BlockNames = append([]string(nil), c.RugCheck.BlockNames...)
+	c.RugCheck.LegacyNotAllowed = append([]string(nil), c.RugCheck.LegacyNotAllowed...)
+	return c
+}
+
+// Validate reports every out of range or malformed field at once.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.LiquidityPool.RadiyumProgramID != "", "liquidity_pool.radiyum_program_id must be set")
+	check(c.LiquidityPool.WsolPcMint != "", "liquidity_pool.wsol_pc_mint must be set")
+	check(c.LiquidityPool.RaydiumCpmmProgramID == "" || c.LiquidityPool.RaydiumCpmmProgramID != c.LiquidityPool.RadiyumProgramID,
+		"liquidity_pool.raydium_cpmm_program_id must differ from liquidity_pool.radiyum_program_id")
+	check(c.LiquidityPool.RaydiumClmmProgramID == "" || (c.LiquidityPool.RaydiumClmmProgramID != c.LiquidityPool.RadiyumProgramID && c.LiquidityPool.RaydiumClmmProgramID != c.LiquidityPool.RaydiumCpmmProgramID),
+		"liquidity_pool.raydium_clmm_program_id must differ from the other pool programs")
+
+	check(c.Tx.FetchTxMaxRetries > 0, "tx.fetch_tx_max_retries must be positive (got %d)", c.Tx.FetchTxMaxRetries)
+	check(c.Tx.FetchTxInitialDelay >= 0, "tx.fetch_tx_initial_delay must not be negative (got %d)", c.Tx.FetchTxInitialDelay)
+	check(c.Tx.SwapTxInitialDelay >= 0, "tx.swap_tx_initial_delay must not be negative (got %d)", c.Tx.SwapTxInitialDelay)
+	check(c.Tx.MaxOpenTimeWait >= 0, "tx.max_open_time_wait must not be negative (got %d)", c.Tx.MaxOpenTimeWait)
+	check(c.Tx.GetTimeout > 0, "tx.get_timeout must be positive (got %d)", c.Tx.GetTimeout)
+	check(c.Tx.ConcurrentTransactions > 0, "tx.concurrent_transactions must be positive (got %d)", c.Tx.ConcurrentTransactions)
+	check(c.Tx.QueueSize > 0, "tx.queue_size must be positive (got %d)", c.Tx.QueueSize)
+	check(c.Tx.QueueMaxAge >= 0, "tx.queue_max_age must not be negative (got %d)", c.Tx.QueueMaxAge)
+	check(c.Tx.RetryDelay > 0, "tx.retry_delay must be positive (got %d)", c.Tx.RetryDelay)
+	check(c.Tx.HTTPMaxRetries >= 0, "tx.http_max_retries must not be negative (got %d)", c.Tx.HTTPMaxRetries)
+	check(c.Tx.HTTPMaxRetryDelay >= c.Tx.RetryDelay, "tx.http_max_retry_delay must be at least tx.retry_delay (got %d)", c.Tx.HTTPMaxRetryDelay)
+	check(c.Tx.HeliusRateLimit >= 0, "tx.helius_rate_limit must not be negative (got %d)", c.Tx.HeliusRateLimit)
+	check(c.Tx.JupiterRateLimit >= 0, "tx.jupiter_rate_limit must not be negative (got %d)", c.Tx.JupiterRateLimit)
+	check(c.Tx.RugCheckRateLimit >= 0, "tx.rug_check_rate_limit must not be negative (got %d)", c.Tx.RugCheckRateLimit)
+	check(c.Tx.DexscreenerRateLimit >= 0, "tx.dexscreener_rate_limit must not be negative (got %d)", c.Tx.DexscreenerRateLimit)
+
+	amount, err := strconv.ParseUint(c.Swap.Amount, 10, 64)
+	check(err == nil && amount > 0, "swap.amount must be a positive number of lamports (got %q)", c.Swap.Amount)
+	check(validBps(c.Swap.SlippageBps), "swap.slippage_bps must be a number between 0 and 10000 (got %q)", c.Swap.SlippageBps)
+	check(c.Swap.PrioFeeMaxLamports >= 0, "swap.prio_fee_max_lamports must not be negative (got %d)", c.Swap.PrioFeeMaxLamports)
+	check(contains(prioLevels, c.Swap.PrioLevel), "swap.prio_level must be one of %s (got %q)", strings.Join(prioLevels, ", "), c.Swap.PrioLevel)
+	check(c.Swap.DbNameTrackerHoldings != "", "swap.db_name_tracker_holdings must be set")
+	check(c.Swap.TokenNotTradable400ErrorRetries > 0, "swap.token_not_tradable_400_error_retries must be positive (got %d)", c.Swap.TokenNotTradable400ErrorRetries)
+	check(c.Swap.TokenNotTradable400ErrorDelay >= 0, "swap.token_not_tradable_400_error_delay must not be negative (got %d)", c.Swap.TokenNotTradable400ErrorDelay)
+	check(c.Swap.MaxRouteHops >= 0, "swap.max_route_hops must not be negative (got %d)", c.Swap.MaxRouteHops)
+	for _, amm := range c.Swap.AllowAmms {
+		check(!contains(c.Swap.DenyAmms, amm), "swap.allow_amms and swap.deny_amms both list %q", amm)
+	}
+	check(contains(routers, c.Swap.Router), "swap.router must be one of %s (got %q)", strings.Join(routers, ", "), c.Swap.Router)
+	check(c.Swap.ComputeUnitLimit > 0 && c.Swap.ComputeUnitLimit <= 1400000, "swap.compute_unit_limit must be between 1 and 1400000 (got %d)", c.Swap.ComputeUnitLimit)
+	check(c.Swap.MaxPriceImpactBps >= 0 && c.Swap.MaxPriceImpactBps <= 10000, "swap.max_price_impact_bps must be between 0 and 10000 (got %d)", c.Swap.MaxPriceImpactBps)
+
+	check(contains(priceSources, c.Sell.PriceSource), "sell.price_source must be one of %s (got %q)", strings.Join(priceSources, ", "), c.Sell.PriceSource)
+	for _, fallback := range c.Sell.PriceFallbacks {
+		check(contains(priceSources, fallback), "sell.price_fallbacks must only contain %s (got %q)", strings.Join(priceSources, ", "), fallback)
+		check(fallback != c.Sell.PriceSource, "sell.price_fallbacks must not repeat sell.price_source %q", fallback)
+	}
+	check(c.Sell.MaxPriceDeviation >= 0, "sell.max_price_deviation must not be negative (got %d)", c.Sell.MaxPriceDeviation)
+	check(c.Sell.MaxPriceAge >= 0, "sell.max_price_age must not be negative (got %d)", c.Sell.MaxPriceAge)
+	check(validBps(c.Sell.SlippageBps), "sell.slippage_bps must be a number between 0 and 10000 (got %q)", c.Sell.SlippageBps)
+	check(c.Sell.PrioFeeMaxLamports >= 0, "sell.prio_fee_max_lamports must not be negative (got %d)", c.Sell.PrioFeeMaxLamports)
+	check(contains(prioLevels, c.Sell.PrioLevel), "sell.prio_level must be one of %s (got %q)", strings.Join(prioLevels, ", "), c.Sell.PrioLevel)
+	check(c.Sell.StopLossPercent >= 0 && c.Sell.StopLossPercent <= 100, "sell.stop_loss_percent must be between 0 and 100 (got %d)", c.Sell.StopLossPercent)
+	check(c.Sell.TakeProfitPercent >= 0, "sell.take_profit_percent must not be negative (got %d)", c.Sell.TakeProfitPercent)
+	check(c.Sell.PriceCheckInterval > 0, "sell.price_check_interval must be positive (got %d)", c.Sell.PriceCheckInterval)
+
+	check(c.RugCheck.MaxAlowedPctTopholders >= 0 && c.RugCheck.MaxAlowedPctTopholders <= 100, "rug_check.max_alowed_pct_topholders must be between 0 and 100 (got %d)", c.RugCheck.MaxAlowedPctTopholders)
+	check(c.RugCheck.MinTotalMarkets >= 0, "rug_check.min_total_markets must not be negative (got %d)", c.RugCheck.MinTotalMarkets)
+	check(c.RugCheck.MinTotalLPProviders >= 0, "rug_check.min_total_lp_providers must not be negative (got %d)", c.RugCheck.MinTotalLPProviders)
+	check(c.RugCheck.MinTotalMarketLiquidity >= 0, "rug_check.min_total_market_liquidity must not be negative (got %d)", c.RugCheck.MinTotalMarketLiquidity)
+	check(contains(pumpFunPolicies, c.RugCheck.PumpFunPolicy), "rug_check.pump_fun_policy must be one of %s (got %q)", strings.Join(pumpFunPolicies, ", "), c.RugCheck.PumpFunPolicy)
+	check(c.RugCheck.MaxScore >= 0, "rug_check.max_score must not be negative (got %d)", c.RugCheck.MaxScore)
+
+	return errors.Join(errs...)
+}
+
+// applyEnvOverrides sets every config field whose SNIPER_* variable is present.
+// Lists are comma separated.
+func applyEnvOverrides(cfg *Config, lookup func(string) (string, bool)) error {
+	var errs []error
+	root := reflect.ValueOf(cfg).Elem()
+	for i := 0; i < root.NumField(); i++ {
+		section := root.Field(i)
+		sectionName := yamlName(root.Type().Field(i))
+		for j := 0; j < section.NumField(); j++ {
+			field := section.Field(j)
+			envVar := EnvPrefix + strings.ToUpper(sectionName+"_"+yamlName(section.Type().Field(j)))
+			value, ok := lookup(envVar)
+			if !ok {
+				continue
+			}
+			if err := setField(field, value); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %v", envVar, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func setField(field reflect.Value, value string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Int:
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", value)
+		}
+		field.SetInt(int64(n))
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", value)
+		}
+		field.SetBool(b)
+	case reflect.Slice:
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		field.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
+
+func yamlName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	if name == "" {
+		return strings.ToLower(field.Name)
+	}
+	return name
+}
+
+func validBps(bps string) bool {
+	n, err := strconv.Atoi(bps)
+	return err == nil && n >= 0 && n <= 10000
+}
+
+func contains(slice []string, item string) bool {
+	for _, s := range slice {
+		if s == item {
+			return true
+		}
+	}
+	return false
+}
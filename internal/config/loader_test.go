@@ -0,0 +1,272 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeConfig writes a YAML config file to a temporary directory and returns
+// its path.
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+		check   func(t *testing.T, cfg *Config)
+	}{
+		{
+			name:    "empty file keeps defaults",
+			content: "",
+			check: func(t *testing.T, cfg *Config) {
+				if !reflect.DeepEqual(*cfg, ConfigVal) {
+					t.Fatalf("got %+v, want the defaults", *cfg)
+				}
+			},
+		},
+		{
+			name: "yaml overrides defaults",
+			content: `
+swap:
+  slippage_bps: "300"
+  allow_amms: [Raydium]
+sell:
+  stop_loss_percent: 25
+  auto_sell: false
+`,
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Swap.SlippageBps != "300" || !reflect.DeepEqual(cfg.Swap.AllowAmms, []string{"Raydium"}) ||
+					cfg.Sell.StopLossPercent != 25 || cfg.Sell.AutoSell {
+					t.Fatalf("overrides not applied: %+v %+v", cfg.Swap, cfg.Sell)
+				}
+				if cfg.Swap.Amount != ConfigVal.Swap.Amount || cfg.Sell.TakeProfitPercent != ConfigVal.Sell.TakeProfitPercent {
+					t.Fatal("fields missing from the file lost their defaults")
+				}
+			},
+		},
+		{
+			name:    "misspelled key",
+			content: "sell:\n  stop_los_percent: 25\n",
+			wantErr: "stop_los_percent",
+		},
+		{
+			name:    "invalid value",
+			content: "sell:\n  stop_loss_percent: 150\n",
+			wantErr: "sell.stop_loss_percent",
+		},
+		{
+			name:    "malformed yaml",
+			content: "sell: [",
+			wantErr: "failed to parse config file",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := Load(writeConfig(t, tt.content))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want one mentioning %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.check(t, cfg)
+		})
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Fatal("expected an error for a missing file")
+	}
+}
+
+func TestLoadEnvOverrides(t *testing.T) {
+	t.Setenv("SNIPER_SELL_STOP_LOSS_PERCENT", "15")
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Sell.StopLossPercent != 15 {
+		t.Fatalf("stop loss %d, want 15 from the environment", cfg.Sell.StopLossPercent)
+	}
+}
+
+func TestApplyEnvOverrides(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr string
+		check   func(t *testing.T, cfg *Config)
+	}{
+		{
+			name: "int",
+			env:  map[string]string{"SNIPER_TX_QUEUE_SIZE": " 20 "},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Tx.QueueSize != 20 {
+					t.Fatalf("queue size %d, want 20", cfg.Tx.QueueSize)
+				}
+			},
+		},
+		{
+			name: "bool",
+			env:  map[string]string{"SNIPER_RUG_CHECK_SIMULATION_MODE": "false"},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.RugCheck.SimulationMode {
+					t.Fatal("simulation mode still enabled")
+				}
+			},
+		},
+		{
+			name: "string",
+			env:  map[string]string{"SNIPER_SWAP_ROUTER": "raydium"},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Swap.Router != "raydium" {
+					t.Fatalf("router %q, want raydium", cfg.Swap.Router)
+				}
+			},
+		},
+		{
+			name: "comma list",
+			env:  map[string]string{"SNIPER_SWAP_DENY_AMMS": "Meteora, ,Orca "},
+			check: func(t *testing.T, cfg *Config) {
+				if !reflect.DeepEqual(cfg.Swap.DenyAmms, []string{"Meteora", "Orca"}) {
+					t.Fatalf("deny amms %q, want [Meteora Orca]", cfg.Swap.DenyAmms)
+				}
+			},
+		},
+		{
+			name:    "malformed int",
+			env:     map[string]string{"SNIPER_TX_QUEUE_SIZE": "many"},
+			wantErr: "SNIPER_TX_QUEUE_SIZE: invalid integer",
+		},
+		{
+			name:    "malformed bool",
+			env:     map[string]string{"SNIPER_SELL_AUTO_SELL": "maybe"},
+			wantErr: "SNIPER_SELL_AUTO_SELL: invalid boolean",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := ConfigVal.clone()
+			err := applyEnvOverrides(&cfg, func(key string) (string, bool) {
+				value, ok := tt.env[key]
+				return value, ok
+			})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want one mentioning %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.check(t, &cfg)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := ConfigVal.Validate(); err != nil {
+		t.Fatalf("defaults do not validate: %v", err)
+	}
+
+	tests := []struct {
+		field  string
+		mutate func(c *Config)
+	}{
+		{"liquidity_pool.radiyum_program_id", func(c *Config) { c.LiquidityPool.RadiyumProgramID = "" }},
+		{"liquidity_pool.wsol_pc_mint", func(c *Config) { c.LiquidityPool.WsolPcMint = "" }},
+		{"liquidity_pool.raydium_cpmm_program_id", func(c *Config) { c.LiquidityPool.RaydiumCpmmProgramID = c.LiquidityPool.RadiyumProgramID }},
+		{"liquidity_pool.raydium_clmm_program_id", func(c *Config) { c.LiquidityPool.RaydiumClmmProgramID = c.LiquidityPool.RaydiumCpmmProgramID }},
+
+		{"tx.fetch_tx_max_retries", func(c *Config) { c.Tx.FetchTxMaxRetries = 0 }},
+		{"tx.fetch_tx_initial_delay", func(c *Config) { c.Tx.FetchTxInitialDelay = -1 }},
+		{"tx.swap_tx_initial_delay", func(c *Config) { c.Tx.SwapTxInitialDelay = -1 }},
+		{"tx.max_open_time_wait", func(c *Config) { c.Tx.MaxOpenTimeWait = -1 }},
+		{"tx.get_timeout", func(c *Config) { c.Tx.GetTimeout = 0 }},
+		{"tx.concurrent_transactions", func(c *Config) { c.Tx.ConcurrentTransactions = 0 }},
+		{"tx.queue_size", func(c *Config) { c.Tx.QueueSize = 0 }},
+		{"tx.queue_max_age", func(c *Config) { c.Tx.QueueMaxAge = -1 }},
+		{"tx.retry_delay", func(c *Config) { c.Tx.RetryDelay = 0 }},
+		{"tx.http_max_retries", func(c *Config) { c.Tx.HTTPMaxRetries = -1 }},
+		{"tx.http_max_retry_delay", func(c *Config) { c.Tx.HTTPMaxRetryDelay = c.Tx.RetryDelay - 1 }},
+		{"tx.helius_rate_limit", func(c *Config) { c.Tx.HeliusRateLimit = -1 }},
+		{"tx.jupiter_rate_limit", func(c *Config) { c.Tx.JupiterRateLimit = -1 }},
+		{"tx.rug_check_rate_limit", func(c *Config) { c.Tx.RugCheckRateLimit = -1 }},
+		{"tx.dexscreener_rate_limit", func(c *Config) { c.Tx.DexscreenerRateLimit = -1 }},
+
+		{"swap.amount", func(c *Config) { c.Swap.Amount = "0" }},
+		{"swap.amount", func(c *Config) { c.Swap.Amount = "0.01" }},
+		{"swap.slippage_bps", func(c *Config) { c.Swap.SlippageBps = "10001" }},
+		{"swap.slippage_bps", func(c *Config) { c.Swap.SlippageBps = "2%" }},
+		{"swap.prio_fee_max_lamports", func(c *Config) { c.Swap.PrioFeeMaxLamports = -1 }},
+		{"swap.prio_level", func(c *Config) { c.Swap.PrioLevel = "urgent" }},
+		{"swap.db_name_tracker_holdings", func(c *Config) { c.Swap.DbNameTrackerHoldings = "" }},
+		{"swap.token_not_tradable_400_error_retries", func(c *Config) { c.Swap.TokenNotTradable400ErrorRetries = 0 }},
+		{"swap.token_not_tradable_400_error_delay", func(c *Config) { c.Swap.TokenNotTradable400ErrorDelay = -1 }},
+		{"swap.max_route_hops", func(c *Config) { c.Swap.MaxRouteHops = -1 }},
+		{"swap.allow_amms and swap.deny_amms", func(c *Config) {
+			c.Swap.AllowAmms = []string{"Raydium"}
+			c.Swap.DenyAmms = []string{"Raydium"}
+		}},
+		{"swap.router", func(c *Config) { c.Swap.Router = "orca" }},
+		{"swap.compute_unit_limit", func(c *Config) { c.Swap.ComputeUnitLimit = 0 }},
+		{"swap.compute_unit_limit", func(c *Config) { c.Swap.ComputeUnitLimit = 1400001 }},
+		{"swap.max_price_impact_bps", func(c *Config) { c.Swap.MaxPriceImpactBps = -1 }},
+		{"swap.max_price_impact_bps", func(c *Config) { c.Swap.MaxPriceImpactBps = 10001 }},
+
+		{"sell.price_source", func(c *Config) { c.Sell.PriceSource = "birdeye" }},
+		{"sell.price_fallbacks must only contain", func(c *Config) { c.Sell.PriceFallbacks = []string{"birdeye"} }},
+		{"sell.price_fallbacks must not repeat", func(c *Config) { c.Sell.PriceFallbacks = []string{c.Sell.PriceSource} }},
+		{"sell.max_price_deviation", func(c *Config) { c.Sell.MaxPriceDeviation = -1 }},
+		{"sell.max_price_age", func(c *Config) { c.Sell.MaxPriceAge = -1 }},
+		{"sell.slippage_bps", func(c *Config) { c.Sell.SlippageBps = "-1" }},
+		{"sell.prio_fee_max_lamports", func(c *Config) { c.Sell.PrioFeeMaxLamports = -1 }},
+		{"sell.prio_level", func(c *Config) { c.Sell.PrioLevel = "" }},
+		{"sell.stop_loss_percent", func(c *Config) { c.Sell.StopLossPercent = -1 }},
+		{"sell.stop_loss_percent", func(c *Config) { c.Sell.StopLossPercent = 101 }},
+		{"sell.take_profit_percent", func(c *Config) { c.Sell.TakeProfitPercent = -1 }},
+		{"sell.price_check_interval", func(c *Config) { c.Sell.PriceCheckInterval = 0 }},
+
+		{"rug_check.max_alowed_pct_topholders", func(c *Config) { c.RugCheck.MaxAlowedPctTopholders = -1 }},
+		{"rug_check.max_alowed_pct_topholders", func(c *Config) { c.RugCheck.MaxAlowedPctTopholders = 101 }},
+		{"rug_check.min_total_markets", func(c *Config) { c.RugCheck.MinTotalMarkets = -1 }},
+		{"rug_check.min_total_lp_providers", func(c *Config) { c.RugCheck.MinTotalLPProviders = -1 }},
+		{"rug_check.min_total_market_liquidity", func(c *Config) { c.RugCheck.MinTotalMarketLiquidity = -1 }},
+		{"rug_check.pump_fun_policy", func(c *Config) { c.RugCheck.PumpFunPolicy = "avoid" }},
+		{"rug_check.max_score", func(c *Config) { c.RugCheck.MaxScore = -1 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			cfg := ConfigVal.clone()
+			tt.mutate(&cfg)
+			err := cfg.Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.field) {
+				t.Fatalf("got error %v, want one mentioning %q", err, tt.field)
+			}
+		})
+	}
+}
+
+func TestValidateReportsEveryError(t *testing.T) {
+	cfg := ConfigVal.clone()
+	cfg.Tx.QueueSize = 0
+	cfg.Sell.StopLossPercent = 101
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "tx.queue_size") || !strings.Contains(err.Error(), "sell.stop_loss_percent") {
+		t.Fatalf("got error %v, want both invalid fields reported", err)
+	}
+}
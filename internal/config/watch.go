@@ -0,0 +1,110 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+var active atomic.Pointer[Config]
+
+// Get returns the active configuration. The value is shared between
+// goroutines and must not be modified; call Get once per operation so a single
+// trade sees consistent settings while later ones pick up reloads.
+func Get() *Config {
+	if cfg := active.Load(); cfg != nil {
+		return cfg
+	}
+	return &ConfigVal
+}
+
+// Set atomically replaces the active configuration.
+func Set(cfg *Config) {
+	active.Store(cfg)
+}
+
+// Watch polls the config file at path every interval and hot-reloads the swap,
+// sell and rug check sections when it changes, until ctx is done. An edit that
+// fails to load or validate is rejected and the previous config stays active.
+// Liquidity pool and tx settings, the holdings database path and the pump.fun
+// policy are only read at start-up.
+func Watch(ctx context.Context, path string, interval time.Duration) {
+	lastMod := modTime(path)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		mod := modTime(path)
+		if mod.Equal(lastMod) {
+			continue
+		}
+		lastMod = mod
+		reload(path)
+	}
+}
+
+func reload(path string) {
+	loaded, err := Load(path)
+	if err != nil {
+		log.Printf("🚫 Rejected config edit in %s, keeping previous config: %v", path, err)
+		return
+	}
+
+	prev := Get()
+	next := prev.clone()
+	next.Swap = loaded.Swap
+	next.Sell = loaded.Sell
+	next.RugCheck = loaded.RugCheck
+	// The holdings database stays open on the path it was first opened with.
+	next.Swap.DbNameTrackerHoldings = prev.Swap.DbNameTrackerHoldings
+	// The pump.fun migration listener is only set up at start-up.
+	next.RugCheck.PumpFunPolicy = prev.RugCheck.PumpFunPolicy
+
+	changes := diff(prev, &next)
+	for _, restartOnly := range diff(&next, loaded) {
+		log.Printf("⚠️ Config change to %s requires a restart and was not applied", restartOnly)
+	}
+	if len(changes) == 0 {
+		return
+	}
+	Set(&next)
+	log.Printf("🔄 Config reloaded from %s: %s", path, strings.Join(changes, "; "))
+}
+
+// diff lists the fields that differ between a and b as
+// "section.field: old -> new".
+func diff(a, b *Config) []string {
+	var changes []string
+	va, vb := reflect.ValueOf(a).Elem(), reflect.ValueOf(b).Elem()
+	for i := 0; i < va.NumField(); i++ {
+		sectionName := yamlName(va.Type().Field(i))
+		sa, sb := va.Field(i), vb.Field(i)
+		for j := 0; j < sa.NumField(); j++ {
+			fa, fb := sa.Field(j).Interface(), sb.Field(j).Interface()
+			if reflect.DeepEqual(fa, fb) {
+				continue
+			}
+			changes = append(changes, fmt.Sprintf("%s.%s: %v -> %v", sectionName, yamlName(sa.Type().Field(j)), fa, fb))
+		}
+	}
+	return changes
+}
+
+func modTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
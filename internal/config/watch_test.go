@@ -0,0 +1,99 @@
+package config
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+// activate makes a copy of the defaults the active config for the duration of
+// the test and captures what is logged meanwhile.
+func activate(t *testing.T) (*Config, *bytes.Buffer) {
+	t.Helper()
+	cfg := ConfigVal.clone()
+	Set(&cfg)
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	t.Cleanup(func() {
+		Set(nil)
+		log.SetOutput(os.Stderr)
+	})
+	return &cfg, &logs
+}
+
+func TestReloadRejectsInvalidEdit(t *testing.T) {
+	prev, logs := activate(t)
+	reload(writeConfig(t, "sell:\n  stop_loss_percent: 150\n"))
+
+	if Get() != prev {
+		t.Fatal("invalid edit replaced the active config")
+	}
+	if !strings.Contains(logs.String(), "Rejected config edit") {
+		t.Fatalf("rejection not logged: %s", logs)
+	}
+}
+
+func TestReloadAppliesHotSections(t *testing.T) {
+	prev, logs := activate(t)
+	reload(writeConfig(t, `
+swap:
+  slippage_bps: "300"
+sell:
+  stop_loss_percent: 25
+rug_check:
+  max_score: 5
+`))
+
+	cfg := Get()
+	if cfg == prev {
+		t.Fatal("valid edit was not applied")
+	}
+	if cfg.Swap.SlippageBps != "300" || cfg.Sell.StopLossPercent != 25 || cfg.RugCheck.MaxScore != 5 {
+		t.Fatalf("edit not applied: %+v %+v %+v", cfg.Swap, cfg.Sell, cfg.RugCheck)
+	}
+	if prev.Sell.StopLossPercent != ConfigVal.Sell.StopLossPercent {
+		t.Fatal("reload modified the previous config in place")
+	}
+	if !strings.Contains(logs.String(), "sell.stop_loss_percent: 10 -> 25") {
+		t.Fatalf("change not logged: %s", logs)
+	}
+}
+
+func TestReloadKeepsRestartOnlyFields(t *testing.T) {
+	prev, logs := activate(t)
+	reload(writeConfig(t, `
+tx:
+  queue_size: 10
+swap:
+  db_name_tracker_holdings: other.db
+rug_check:
+  pump_fun_policy: prefer
+`))
+
+	if Get() != prev {
+		t.Fatal("restart-only edit replaced the active config")
+	}
+	for _, field := range []string{"tx.queue_size", "swap.db_name_tracker_holdings", "rug_check.pump_fun_policy"} {
+		if !strings.Contains(logs.String(), "Config change to "+field) {
+			t.Errorf("%s not reported as requiring a restart: %s", field, logs)
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	a := ConfigVal.clone()
+	b := ConfigVal.clone()
+	if changes := diff(&a, &b); len(changes) != 0 {
+		t.Fatalf("got changes %v between equal configs", changes)
+	}
+
+	b.Tx.QueueSize = 10
+	b.Sell.PriceFallbacks = nil
+	changes := diff(&a, &b)
+	want := []string{"tx.queue_size: 50 -> 10", "sell.price_fallbacks: [jup] -> []"}
+	if strings.Join(changes, "; ") != strings.Join(want, "; ") {
+		t.Fatalf("got %v, want %v", changes, want)
+	}
+}
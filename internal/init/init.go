@@ -1,7 +1,8 @@
 package init
 
 import (
-	"log"
+	"errors"
+	"fmt"
 	"net/url"
 	"os"
 	"strings"
@@ -9,6 +10,10 @@ import (
 	"github.com/joho/godotenv"
 )
 
+// DefaultDexHTTPSTokenPairs is the Dexscreener token pairs endpoint used when
+// DEX_HTTPS_TOKEN_PAIRS is not set.
+const DefaultDexHTTPSTokenPairs = "https://api.dexscreener.com/latest/dex/tokens"
+
 // EnvConfig holds the required configuration values.
 type EnvConfig struct {
 	PrivKeyWallet        string
@@ -19,102 +24,110 @@ type EnvConfig struct {
 	JupHTTPSSwapURI      string
 	JupHTTPSPriceURI     string
 	DexHTTPSLatestTokens string
+	DexHTTPSTokenPairs   string // Optional, defaults to DefaultDexHTTPSTokenPairs
 }
 
-func init() {
+// Load reads the environment (and a .env file, if present) into an EnvConfig.
+// All validation failures are reported together in the returned error.
+func Load() (*EnvConfig, error) {
 	// Load .env file if available
 	_ = godotenv.Load()
 
-	requiredEnvVars := []string{
-		"PRIV_KEY_WALLET",
-		"HELIUS_HTTPS_URI",
-		"HELIUS_WSS_URI",
-		"HELIUS_HTTPS_URI_TX",
-		"JUP_HTTPS_QUOTE_URI",
-		"JUP_HTTPS_SWAP_URI",
-		"JUP_HTTPS_PRICE_URI",
-		"DEX_HTTPS_LATEST_TOKENS",
+	env := &EnvConfig{
+		PrivKeyWallet:        os.Getenv("PRIV_KEY_WALLET"),
+		HeliusHTTPSURI:       os.Getenv("HELIUS_HTTPS_URI"),
+		HeliusWSSURI:         os.Getenv("HELIUS_WSS_URI"),
+		HeliusHTTPSURITx:     os.Getenv("HELIUS_HTTPS_URI_TX"),
+		JupHTTPSQuoteURI:     os.Getenv("JUP_HTTPS_QUOTE_URI"),
+		JupHTTPSSwapURI:      os.Getenv("JUP_HTTPS_SWAP_URI"),
+		JupHTTPSPriceURI:     os.Getenv("JUP_HTTPS_PRICE_URI"),
+		DexHTTPSLatestTokens: os.Getenv("DEX_HTTPS_LATEST_TOKENS"),
+		DexHTTPSTokenPairs:   os.Getenv("DEX_HTTPS_TOKEN_PAIRS"),
+	}
+	if env.DexHTTPSTokenPairs == "" {
+		env.DexHTTPSTokenPairs = DefaultDexHTTPSTokenPairs
 	}
+	if err := env.Validate(); err != nil {
+		return nil, err
+	}
+	return env, nil
+}
+
+// Validate checks that every required value is present and well formed.
+func (env *EnvConfig) Validate() error {
+	var errs []error
 
 	// Check for missing variables (allow PRIV_KEY_WALLET to be empty)
+	required := []struct {
+		name  string
+		value string
+	}{
+		{"HELIUS_HTTPS_URI", env.HeliusHTTPSURI},
+		{"HELIUS_WSS_URI", env.HeliusWSSURI},
+		{"HELIUS_HTTPS_URI_TX", env.HeliusHTTPSURITx},
+		{"JUP_HTTPS_QUOTE_URI", env.JupHTTPSQuoteURI},
+		{"JUP_HTTPS_SWAP_URI", env.JupHTTPSSwapURI},
+		{"JUP_HTTPS_PRICE_URI", env.JupHTTPSPriceURI},
+		{"DEX_HTTPS_LATEST_TOKENS", env.DexHTTPSLatestTokens},
+	}
 	var missingVars []string
-	for _, envVar := range requiredEnvVars {
-		if envVar == "PRIV_KEY_WALLET" {
-			continue
-		}
-		if os.Getenv(envVar) == "" {
-			missingVars = append(missingVars, envVar)
+	for _, envVar := range required {
+		if envVar.value == "" {
+			missingVars = append(missingVars, envVar.name)
 		}
 	}
 	if len(missingVars) > 0 {
-		log.Printf("🚫 Missing required environment variables: %s", strings.Join(missingVars, ", "))
-		os.Exit(1)
+		errs = append(errs, fmt.Errorf("missing required environment variables: %s", strings.Join(missingVars, ", ")))
 	}
 
 	// Validate PRIV_KEY_WALLET length if provided
-	privKeyWallet := os.Getenv("PRIV_KEY_WALLET")
-	if privKeyWallet != "" {
-		length := len(privKeyWallet)
+	if env.PrivKeyWallet != "" {
+		length := len(env.PrivKeyWallet)
 		if length != 87 && length != 88 {
-			log.Printf("🚫 PRIV_KEY_WALLET must be 87 or 88 characters long (got %d)", length)
-			os.Exit(1)
+			errs = append(errs, fmt.Errorf("PRIV_KEY_WALLET must be 87 or 88 characters long (got %d)", length))
 		}
 	}
 
 	// Helper to validate URL environment variables
-	validateURL := func(envVar, expectedProtocol string, checkApiKey bool) {
-		value := os.Getenv(envVar)
+	validateURL := func(envVar, value, expectedProtocol string, checkApiKey bool) {
 		if value == "" {
-			log.Printf("🚫 %s is missing or empty", envVar)
-			os.Exit(1)
+			// Already reported as missing.
+			return
 		}
 
 		parsed, err := url.Parse(value)
 		if err != nil {
-			log.Printf("🚫 Failed to parse %s: %v", envVar, err)
-			os.Exit(1)
+			errs = append(errs, fmt.Errorf("failed to parse %s: %v", envVar, err))
+			return
 		}
 
 		expectedScheme := strings.TrimSuffix(expectedProtocol, ":")
 		if parsed.Scheme != expectedScheme {
-			log.Printf("🚫 %s must start with %s", envVar, expectedProtocol)
-			os.Exit(1)
+			errs = append(errs, fmt.Errorf("%s must start with %s", envVar, expectedProtocol))
 		}
 
 		if checkApiKey {
 			apiKey := parsed.Query().Get("api-key")
 			if strings.TrimSpace(apiKey) == "" {
-				log.Printf("🚫 The 'api-key' parameter is missing or empty in the URL: %s", value)
-				os.Exit(1)
+				errs = append(errs, fmt.Errorf("the 'api-key' parameter is missing or empty in the URL: %s", value))
 			}
 		}
 	}
 
 	// Validate the URL variables with appropriate protocols and API key checks
-	validateURL("HELIUS_HTTPS_URI", "https:", true)
-	validateURL("HELIUS_WSS_URI", "wss:", true)
-	validateURL("HELIUS_HTTPS_URI_TX", "https:", true)
-	validateURL("JUP_HTTPS_QUOTE_URI", "https:", false)
-	validateURL("JUP_HTTPS_SWAP_URI", "https:", false)
-	validateURL("JUP_HTTPS_PRICE_URI", "https:", false)
-	validateURL("DEX_HTTPS_LATEST_TOKENS", "https:", false)
+	validateURL("HELIUS_HTTPS_URI", env.HeliusHTTPSURI, "https:", true)
+	validateURL("HELIUS_WSS_URI", env.HeliusWSSURI, "wss:", true)
+	validateURL("HELIUS_HTTPS_URI_TX", env.HeliusHTTPSURITx, "https:", true)
+	validateURL("JUP_HTTPS_QUOTE_URI", env.JupHTTPSQuoteURI, "https:", false)
+	validateURL("JUP_HTTPS_SWAP_URI", env.JupHTTPSSwapURI, "https:", false)
+	validateURL("JUP_HTTPS_PRICE_URI", env.JupHTTPSPriceURI, "https:", false)
+	validateURL("DEX_HTTPS_LATEST_TOKENS", env.DexHTTPSLatestTokens, "https:", false)
+	validateURL("DEX_HTTPS_TOKEN_PAIRS", env.DexHTTPSTokenPairs, "https:", false)
 
 	// Check for "{function}" in HELIUS_HTTPS_URI_TX
-	heliusHTTPSURITx := os.Getenv("HELIUS_HTTPS_URI_TX")
-	if strings.Contains(heliusHTTPSURITx, "{function}") {
-		log.Printf("🚫 HELIUS_HTTPS_URI_TX contains {function}. Check your configuration.")
-		os.Exit(1)
+	if strings.Contains(env.HeliusHTTPSURITx, "{function}") {
+		errs = append(errs, fmt.Errorf("HELIUS_HTTPS_URI_TX contains {function}. Check your configuration"))
 	}
 
-	// Build the configuration struct (if needed, you can assign it to a package-level variable)
-	_ = &EnvConfig{
-		PrivKeyWallet:        privKeyWallet,
-		HeliusHTTPSURI:       os.Getenv("HELIUS_HTTPS_URI"),
-		HeliusWSSURI:         os.Getenv("HELIUS_WSS_URI"),
-		HeliusHTTPSURITx:     heliusHTTPSURITx,
-		JupHTTPSQuoteURI:     os.Getenv("JUP_HTTPS_QUOTE_URI"),
-		JupHTTPSSwapURI:      os.Getenv("JUP_HTTPS_SWAP_URI"),
-		JupHTTPSPriceURI:     os.Getenv("JUP_HTTPS_PRICE_URI"),
-		DexHTTPSLatestTokens: os.Getenv("DEX_HTTPS_LATEST_TOKENS"),
-	}
+	return errors.Join(errs...)
 }
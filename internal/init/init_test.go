@@ -0,0 +1,126 @@
+package init
+
+import (
+	"strings"
+	"testing"
+)
+
+func validEnv() EnvConfig {
+	return EnvConfig{
+		HeliusHTTPSURI:       "https://mainnet.helius-rpc.com/?api-key=key",
+		HeliusWSSURI:         "wss://mainnet.helius-rpc.com/?api-key=key",
+		HeliusHTTPSURITx:     "https://api.helius.xyz/v0/transactions/?api-key=key",
+		JupHTTPSQuoteURI:     "https://quote-api.jup.ag/v6/quote",
+		JupHTTPSSwapURI:      "https://quote-api.jup.ag/v6/swap",
+		JupHTTPSPriceURI:     "https://api.jup.ag/price/v2",
+		DexHTTPSLatestTokens: "https://api.dexscreener.com/token-profiles/latest/v1",
+		DexHTTPSTokenPairs:   DefaultDexHTTPSTokenPairs,
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if env := validEnv(); env.Validate() != nil {
+		t.Fatalf("valid env rejected: %v", env.Validate())
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(env *EnvConfig)
+		want   []string
+	}{
+		{
+			name:   "missing variables are listed together",
+			mutate: func(env *EnvConfig) { env.HeliusWSSURI, env.JupHTTPSSwapURI = "", "" },
+			want:   []string{"missing required environment variables: HELIUS_WSS_URI, JUP_HTTPS_SWAP_URI"},
+		},
+		{
+			name:   "wallet of the wrong length",
+			mutate: func(env *EnvConfig) { env.PrivKeyWallet = "short" },
+			want:   []string{"PRIV_KEY_WALLET must be 87 or 88 characters long (got 5)"},
+		},
+		{
+			name:   "wrong scheme",
+			mutate: func(env *EnvConfig) { env.HeliusWSSURI = "https://mainnet.helius-rpc.com/?api-key=key" },
+			want:   []string{"HELIUS_WSS_URI must start with wss:"},
+		},
+		{
+			name:   "missing api key",
+			mutate: func(env *EnvConfig) { env.HeliusHTTPSURI = "https://mainnet.helius-rpc.com/?api-key=" },
+			want:   []string{"'api-key' parameter is missing or empty"},
+		},
+		{
+			name:   "unparsable url",
+			mutate: func(env *EnvConfig) { env.JupHTTPSPriceURI = "https://api.jup.ag/%zz" },
+			want:   []string{"failed to parse JUP_HTTPS_PRICE_URI"},
+		},
+		{
+			name:   "token pairs endpoint over http",
+			mutate: func(env *EnvConfig) { env.DexHTTPSTokenPairs = "http://api.dexscreener.com/latest/dex/tokens" },
+			want:   []string{"DEX_HTTPS_TOKEN_PAIRS must start with https:"},
+		},
+		{
+			name:   "function placeholder",
+			mutate: func(env *EnvConfig) { env.HeliusHTTPSURITx = "https://api.helius.xyz/v0/{function}/?api-key=key" },
+			want:   []string{"HELIUS_HTTPS_URI_TX contains {function}"},
+		},
+		{
+			name: "every failure is reported",
+			mutate: func(env *EnvConfig) {
+				env.DexHTTPSLatestTokens = ""
+				env.PrivKeyWallet = "short"
+				env.JupHTTPSQuoteURI = "http://quote-api.jup.ag/v6/quote"
+			},
+			want: []string{"DEX_HTTPS_LATEST_TOKENS", "PRIV_KEY_WALLET", "JUP_HTTPS_QUOTE_URI must start with https:"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := validEnv()
+			tt.mutate(&env)
+			err := env.Validate()
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not mention %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	env := validEnv()
+	for name, value := range map[string]string{
+		"PRIV_KEY_WALLET":         "",
+		"HELIUS_HTTPS_URI":        env.HeliusHTTPSURI,
+		"HELIUS_WSS_URI":          env.HeliusWSSURI,
+		"HELIUS_HTTPS_URI_TX":     env.HeliusHTTPSURITx,
+		"JUP_HTTPS_QUOTE_URI":     env.JupHTTPSQuoteURI,
+		"JUP_HTTPS_SWAP_URI":      env.JupHTTPSSwapURI,
+		"JUP_HTTPS_PRICE_URI":     env.JupHTTPSPriceURI,
+		"DEX_HTTPS_LATEST_TOKENS": env.DexHTTPSLatestTokens,
+		"DEX_HTTPS_TOKEN_PAIRS":   "",
+	} {
+		t.Setenv(name, value)
+	}
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *loaded != env {
+		t.Fatalf("got %+v, want %+v", *loaded, env)
+	}
+
+	t.Setenv("DEX_HTTPS_TOKEN_PAIRS", "https://dex.example.com/tokens")
+	if loaded, err := Load(); err != nil || loaded.DexHTTPSTokenPairs != "https://dex.example.com/tokens" {
+		t.Fatalf("got (%v, %v), want the token pairs endpoint from the environment", loaded, err)
+	}
+
+	t.Setenv("HELIUS_WSS_URI", "")
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "HELIUS_WSS_URI") {
+		t.Fatalf("got error %v, want HELIUS_WSS_URI reported missing", err)
+	}
+}
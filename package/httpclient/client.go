@@ -0,0 +1,236 @@
+package httpclient
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Options configures a Client.
+type Options struct {
+	Timeout    time.Duration      // Timeout of a single attempt, 0 for none
+	MaxRetries int                // Retries after the first attempt
+	BaseDelay  time.Duration      // Backoff before the first retry, doubled for every further one
+	MaxDelay   time.Duration      // Upper bound of the backoff
+	RateLimits map[string]float64 // Requests per second by host, hosts not listed are not limited
+}
+
+// Client is an http.Client shared by every outgoing API call. Requests are
+// rate limited per host, and network errors, 429s and 5xx responses are
+// retried with exponential backoff and jitter until the request's context is
+// done.
+type Client struct {
+	*http.Client
+	transport *transport
+}
+
+// New creates a Client.
+func New(opts Options) *Client {
+	t := &transport{
+		base:     http.DefaultTransport,
+		opts:     opts,
+		limiters: make(map[string]*bucket, len(opts.RateLimits)),
+		metrics:  make(map[string]*HostMetrics),
+	}
+	for host, rps := range opts.RateLimits {
+		if rps > 0 {
+			t.limiters[host] = newBucket(rps)
+		}
+	}
+	return &Client{Client: &http.Client{Transport: t}, transport: t}
+}
+
+// HostMetrics counts the requests sent to one host.
+type HostMetrics struct {
+	Requests    int // Logical requests, retries not included
+	Retries     int
+	RateLimited int // 429 responses received
+	Failures    int // Requests that still failed after all retries
+}
+
+// Metrics returns a snapshot of the counters of every host contacted so far.
+func (c *Client) Metrics() map[string]HostMetrics {
+	t := c.transport
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	snapshot := make(map[string]HostMetrics, len(t.metrics))
+	for host, m := range t.metrics {
+		snapshot[host] = *m
+	}
+	return snapshot
+}
+
+// Backoff returns the delay before retry number attempt (starting at 1): a
+// random duration up to base doubled attempt-1 times, capped at max.
+func Backoff(attempt int, base, max time.Duration) time.Duration {
+	delay := max
+	if attempt < 32 {
+		if d := base << (attempt - 1); d >= 0 && d < max {
+			delay = d
+		}
+	}
+	// Full jitter keeps clients that failed together from retrying together.
+	return time.Duration(rand.Int63n(int64(delay) + 1))
+}
+
+type transport struct {
+	base     http.RoundTripper
+	opts     Options
+	limiters map[string]*bucket
+
+	mu      sync.Mutex
+	metrics map[string]*HostMetrics
+}
+
+func (t *transport) count(host string, update func(*HostMetrics)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	m, ok := t.metrics[host]
+	if !ok {
+		m = &HostMetrics{}
+		t.metrics[host] = m
+	}
+	update(m)
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	host := req.URL.Hostname()
+	t.count(host, func(m *HostMetrics) { m.Requests++ })
+
+	for attempt := 0; ; attempt++ {
+		if limiter, ok := t.limiters[host]; ok {
+			if err := limiter.wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := t.attempt(req, attempt)
+		retryable := err != nil && ctx.Err() == nil ||
+			resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500)
+		if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+			t.count(host, func(m *HostMetrics) { m.RateLimited++ })
+		}
+		if !retryable {
+			return resp, err
+		}
+		if attempt >= t.opts.MaxRetries || (req.Body != nil && req.GetBody == nil) {
+			t.count(host, func(m *HostMetrics) { m.Failures++ })
+			return resp, err
+		}
+
+		delay := Backoff(attempt+1, t.opts.BaseDelay, t.opts.MaxDelay)
+		if resp != nil {
+			if retryAfter := parseRetryAfter(resp); retryAfter > delay {
+				delay = retryAfter
+			}
+			// Drain so the connection can be reused.
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		t.count(host, func(m *HostMetrics) { m.Retries++ })
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// attempt sends one try of req, with a fresh body and its own timeout.
+func (t *transport) attempt(req *http.Request, attempt int) (*http.Response, error) {
+	ctx, cancel := req.Context(), context.CancelFunc(func() {})
+	if t.opts.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, t.opts.Timeout)
+	}
+	try := req.Clone(ctx)
+	if attempt > 0 && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		try.Body = body
+	}
+
+	resp, err := t.base.RoundTrip(try)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// The attempt's timeout also covers reading the body; release it on Close.
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
+
+func parseRetryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// bucket is a token bucket refilled at rate tokens per second and holding at
+// most one second worth of tokens.
+type bucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(rate float64) *bucket {
+	burst := rate
+	if burst < 1 {
+		burst = 1
+	}
+	return &bucket{rate: rate, burst: burst, tokens: burst, last: time.Now()}
+}
+
+// wait blocks until a token is available or ctx is done.
+func (b *bucket) wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
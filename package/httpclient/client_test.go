@@ -0,0 +1,148 @@
+package httpclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetriesUntilSuccess(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "payload" {
+			t.Errorf("attempt %d got body %q", atomic.LoadInt32(&calls)+1, body)
+		}
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.Write([]byte("ok"))
+		}
+	}))
+	defer server.Close()
+
+	client := New(Options{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond})
+	resp, err := client.Post(server.URL, "text/plain", strings.NewReader("payload"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("got status %d, want 200", resp.StatusCode)
+	}
+
+	host := mustHost(t, server.URL)
+	want := HostMetrics{Requests: 1, Retries: 2, RateLimited: 1}
+	if got := client.Metrics()[host]; got != want {
+		t.Fatalf("got metrics %+v, want %+v", got, want)
+	}
+}
+
+func TestGivesUpAfterMaxRetries(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := New(Options{MaxRetries: 2, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond})
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadGateway || atomic.LoadInt32(&calls) != 3 {
+		t.Fatalf("got status %d after %d calls, want 502 after 3", resp.StatusCode, calls)
+	}
+	if got := client.Metrics()[mustHost(t, server.URL)].Failures; got != 1 {
+		t.Fatalf("got %d failures, want 1", got)
+	}
+}
+
+func TestDoesNotRetryClientErrors(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	resp, err := New(Options{MaxRetries: 3}).Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if calls != 1 {
+		t.Fatalf("got %d calls, want 1", calls)
+	}
+}
+
+func TestStopsOnContextCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+
+	client := New(Options{MaxRetries: 100, BaseDelay: time.Hour, MaxDelay: time.Hour})
+	start := time.Now()
+	if _, err := client.Do(req); err == nil {
+		t.Fatal("expected an error once the context is done")
+	}
+	if time.Since(start) > time.Second {
+		t.Fatal("retry backoff ignored the context")
+	}
+}
+
+func TestRateLimitsPerHost(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	client := New(Options{RateLimits: map[string]float64{mustHost(t, server.URL): 20}})
+	start := time.Now()
+	// The first 20 requests use the burst, the next 5 wait 50ms each.
+	for i := 0; i < 25; i++ {
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Fatalf("25 requests at 20/s took %v, want at least 200ms", elapsed)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for attempt := 1; attempt <= 40; attempt++ {
+		limit := 10 * time.Second
+		if attempt < 5 {
+			limit = time.Second << (attempt - 1)
+		}
+		if d := Backoff(attempt, time.Second, 10*time.Second); d < 0 || d > limit {
+			t.Fatalf("attempt %d: got %v, want at most %v", attempt, d, limit)
+		}
+	}
+}
+
+func mustHost(t *testing.T, rawURL string) string {
+	t.Helper()
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u.Hostname()
+}
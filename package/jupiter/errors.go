@@ -0,0 +1,85 @@
+package jupiter
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ErrorCode is the errorCode reported in a Jupiter API error body.
+type ErrorCode string
+
+const (
+	TokenNotTradable     ErrorCode = "TOKEN_NOT_TRADABLE"
+	NoRoutesFound        ErrorCode = "NO_ROUTES_FOUND"
+	CouldNotFindAnyRoute ErrorCode = "COULD_NOT_FIND_ANY_ROUTE"
+	CircularArbitrage    ErrorCode = "CIRCULAR_ARBITRAGE_IS_DISABLED"
+	RateLimited          ErrorCode = "RATE_LIMITED" // HTTP 429, Jupiter sends no errorCode
+	ServerError          ErrorCode = "SERVER_ERROR" // HTTP 5xx without an errorCode
+	UnknownError         ErrorCode = "UNKNOWN"
+)
+
+// Sentinel errors for errors.Is, matching any APIError with the same code.
+var (
+	ErrTokenNotTradable     = &APIError{Code: TokenNotTradable}
+	ErrNoRoutesFound        = &APIError{Code: NoRoutesFound}
+	ErrCouldNotFindAnyRoute = &APIError{Code: CouldNotFindAnyRoute}
+	ErrCircularArbitrage    = &APIError{Code: CircularArbitrage}
+	ErrRateLimited          = &APIError{Code: RateLimited}
+	ErrServerError          = &APIError{Code: ServerError}
+)
+
+// APIError is returned when a Jupiter API answers with a non-200 status.
+type APIError struct {
+	StatusCode int
+	Code       ErrorCode
+	Message    string
+	RetryAfter time.Duration // from the Retry-After header, 0 if absent
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("jupiter API returned status %d (%s): %s", e.StatusCode, e.Code, e.Message)
+}
+
+// Is reports whether target is an APIError with the same code.
+func (e *APIError) Is(target error) bool {
+	t, ok := target.(*APIError)
+	return ok && t.Code == e.Code
+}
+
+// NoRoute reports whether the error means no route exists between the mints.
+func (e *APIError) NoRoute() bool {
+	return e.Code == NoRoutesFound || e.Code == CouldNotFindAnyRoute
+}
+
+// parseAPIError builds an APIError from a non-200 response and its body.
+func parseAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: resp.StatusCode, Code: UnknownError, Message: string(body)}
+
+	var data struct {
+		Error     string `json:"error"`
+		ErrorCode string `json:"errorCode"`
+	}
+	if json.Unmarshal(body, &data) == nil {
+		if data.Error != "" {
+			apiErr.Message = data.Error
+		}
+		if data.ErrorCode != "" {
+			apiErr.Code = ErrorCode(data.ErrorCode)
+		}
+	}
+	if apiErr.Code == UnknownError {
+		switch {
+		case resp.StatusCode == http.StatusTooManyRequests:
+			apiErr.Code = RateLimited
+		case resp.StatusCode >= 500:
+			apiErr.Code = ServerError
+		}
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		apiErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+	return apiErr
+}
@@ -0,0 +1,71 @@
+package jupiter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/low4ey/sniper/package/models"
+)
+
+// Quote is a parsed swap quote together with the response it was parsed
+// from. The swap API must be given Raw unchanged.
+type Quote struct {
+	models.QuoteResponse
+	Raw json.RawMessage
+}
+
+// QuoteClient requests swap quotes from the Jupiter quote API
+// (JUP_HTTPS_QUOTE_URI).
+type QuoteClient struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewQuoteClient creates a QuoteClient for the quote endpoint at baseURL.
+func NewQuoteClient(baseURL string, client *http.Client) *QuoteClient {
+	return &QuoteClient{
+		baseURL: baseURL,
+		client:  client,
+	}
+}
+
+// Quote asks for the best route swapping amount (in the smallest unit of
+// inputMint) into outputMint with at most slippageBps slippage. API failures
+// are returned as *APIError.
+func (q *QuoteClient) Quote(ctx context.Context, inputMint, outputMint, amount, slippageBps string) (*Quote, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, q.baseURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	params := req.URL.Query()
+	params.Set("inputMint", inputMint)
+	params.Set("outputMint", outputMint)
+	params.Set("amount", amount)
+	params.Set("slippageBps", slippageBps)
+	req.URL.RawQuery = params.Encode()
+
+	resp, err := q.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseAPIError(resp, body)
+	}
+	quote := &Quote{Raw: body}
+	if err := json.Unmarshal(body, &quote.QuoteResponse); err != nil {
+		return nil, fmt.Errorf("failed to parse quote: %v", err)
+	}
+	if quote.OutAmount == 0 {
+		return nil, fmt.Errorf("quote for %s has no output", outputMint)
+	}
+	return quote, nil
+}
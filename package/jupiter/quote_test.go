@@ -0,0 +1,109 @@
+package jupiter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const quoteResponse = `{
+	"inputMint": "So11111111111111111111111111111111111111112",
+	"inAmount": "10000000",
+	"outputMint": "7GCihgDB8fe6KNjn2MYtkzZcRjQy3t9GHdC8uHYmW2hr",
+	"outAmount": "123456789",
+	"otherAmountThreshold": "120987653",
+	"swapMode": "ExactIn",
+	"slippageBps": 200,
+	"platformFee": null,
+	"priceImpactPct": "0.0123",
+	"routePlan": [
+		{
+			"swapInfo": {
+				"ammKey": "58oQChx4yWmvKdwLLZzBi4ChoCc2fqCUWBkwMihLYQo2",
+				"label": "Raydium",
+				"inputMint": "So11111111111111111111111111111111111111112",
+				"outputMint": "7GCihgDB8fe6KNjn2MYtkzZcRjQy3t9GHdC8uHYmW2hr",
+				"inAmount": "10000000",
+				"outAmount": "123456789",
+				"feeAmount": "25000",
+				"feeMint": "So11111111111111111111111111111111111111112"
+			},
+			"percent": 100
+		}
+	],
+	"contextSlot": 300000000,
+	"timeTaken": 0.0042
+}`
+
+func TestQuote(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("inputMint") == "" || q.Get("outputMint") == "" || q.Get("amount") != "10000000" || q.Get("slippageBps") != "200" {
+			http.Error(w, `{"error":"bad request"}`, http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(quoteResponse))
+	}))
+	defer server.Close()
+
+	quote, err := NewQuoteClient(server.URL, http.DefaultClient).Quote(context.Background(),
+		"So11111111111111111111111111111111111111112", "7GCihgDB8fe6KNjn2MYtkzZcRjQy3t9GHdC8uHYmW2hr", "10000000", "200")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if quote.InAmount != 10000000 || quote.OutAmount != 123456789 || quote.OtherAmountThreshold != 120987653 {
+		t.Fatalf("got amounts %d -> %d (min %d)", quote.InAmount, quote.OutAmount, quote.OtherAmountThreshold)
+	}
+	if quote.PriceImpactBps() != 123 {
+		t.Fatalf("got price impact %v bps, want 123", quote.PriceImpactBps())
+	}
+	if len(quote.RoutePlan) != 1 || quote.RoutePlan[0].SwapInfo.Label != "Raydium" || quote.RoutePlan[0].SwapInfo.FeeAmount != 25000 {
+		t.Fatalf("unexpected route plan: %+v", quote.RoutePlan)
+	}
+	if string(quote.Raw) != quoteResponse {
+		t.Fatal("raw quote was not kept unchanged")
+	}
+}
+
+func TestQuoteAPIErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		header string
+		want   error
+	}{
+		{"not tradable", http.StatusBadRequest, `{"error":"The token is not tradable","errorCode":"TOKEN_NOT_TRADABLE"}`, "", ErrTokenNotTradable},
+		{"no routes", http.StatusBadRequest, `{"error":"No routes found","errorCode":"NO_ROUTES_FOUND"}`, "", ErrNoRoutesFound},
+		{"could not find route", http.StatusBadRequest, `{"error":"Could not find any route","errorCode":"COULD_NOT_FIND_ANY_ROUTE"}`, "", ErrCouldNotFindAnyRoute},
+		{"rate limited", http.StatusTooManyRequests, `Too Many Requests`, "3", ErrRateLimited},
+		{"server error", http.StatusBadGateway, `<html>bad gateway</html>`, "", ErrServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.header != "" {
+					w.Header().Set("Retry-After", tt.header)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			_, err := NewQuoteClient(server.URL, http.DefaultClient).Quote(context.Background(), "in", "out", "1", "50")
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("got %v, want %v", err, tt.want)
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status {
+				t.Fatalf("got %v, want an *APIError with status %d", err, tt.status)
+			}
+			if tt.header != "" && apiErr.RetryAfter != 3*time.Second {
+				t.Fatalf("got Retry-After %v, want 3s", apiErr.RetryAfter)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package listener
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/ws"
+)
+
+// Initialize2Log is the log line fragment emitted by the Raydium AMM program
+// when a new liquidity pool is created.
+const Initialize2Log = "initialize2"
+
+// Listener watches the logs of a program over a WebSocket connection and emits
+// the signatures of pool creation transactions.
+type Listener struct {
+	WssURL     string
+	ProgramID  solana.PublicKey
+	Commitment rpc.CommitmentType
+	MinBackoff time.Duration // Delay before the first reconnect attempt
+	MaxBackoff time.Duration // Upper bound for the delay between reconnect attempts
+	// Match reports whether the logs of a transaction show a pool creation.
+	// It defaults to looking for Initialize2Log.
+	Match func(logs []string) bool
+}
+
+// New creates a Listener for the given WebSocket endpoint and program id. Any
+// other account id works too, reporting the pool creations that mention it.
+func New(wssURL, programID string) (*Listener, error) {
+	pubKey, err := solana.PublicKeyFromBase58(programID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid program id %q: %v", programID, err)
+	}
+	return &Listener{
+		WssURL:     wssURL,
+		ProgramID:  pubKey,
+		Commitment: rpc.CommitmentProcessed,
+		MinBackoff: 500 * time.Millisecond,
+		MaxBackoff: 30 * time.Second,
+		Match:      containsInitialize2,
+	}, nil
+}
+
+// Listen subscribes to the program logs and returns a channel of pool creation
+// signatures. Dropped connections are re-established with exponential backoff
+// and the subscription is renewed. The channel is closed once ctx is done.
+func (l *Listener) Listen(ctx context.Context) <-chan string {
+	signatures := make(chan string)
+	go func() {
+		defer close(signatures)
+		attempt := 0
+		for {
+			connected, err := l.subscribe(ctx, signatures)
+			if ctx.Err() != nil {
+				return
+			}
+			if connected {
+				attempt = 0
+			}
+			delay := l.backoff(attempt)
+			attempt++
+			log.Printf("⛔ WebSocket subscription lost: %v. Reconnecting in %v...", err, delay)
+
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+	}()
+	return signatures
+}
+
+// subscribe runs a single connection until it fails or ctx is done. It reports
+// whether the subscription was established before the failure.
+func (l *Listener) subscribe(ctx context.Context, signatures chan<- string) (bool, error) {
+	client, err := ws.Connect(ctx, l.WssURL)
+	if err != nil {
+		return false, err
+	}
+	defer client.Close()
+
+	sub, err := client.LogsSubscribeMentions(l.ProgramID, l.Commitment)
+	if err != nil {
+		return false, err
+	}
+	defer sub.Unsubscribe()
+	log.Printf("🔓 Subscribed to logs of %s", l.ProgramID)
+
+	for {
+		result, err := sub.Recv(ctx)
+		if err != nil {
+			return true, err
+		}
+		if result.Value.Err != nil || !l.Match(result.Value.Logs) {
+			continue
+		}
+
+		select {
+		case signatures <- result.Value.Signature.String():
+		case <-ctx.Done():
+			return true, ctx.Err()
+		}
+	}
+}
+
+func (l *Listener) backoff(attempt int) time.Duration {
+	delay := l.MinBackoff
+	for i := 0; i < attempt && delay < l.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > l.MaxBackoff {
+		delay = l.MaxBackoff
+	}
+	return delay
+}
+
+func containsInitialize2(logs []string) bool {
+	for _, line := range logs {
+		if strings.Contains(line, Initialize2Log) {
+			return true
+		}
+	}
+	return false
+}
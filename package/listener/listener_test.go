@@ -0,0 +1,183 @@
+package listener
+
+import (
+	"context"
+	"crypto/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gorilla/websocket"
+)
+
+const raydiumProgramID = "675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8"
+
+// standIn is a minimal logsSubscribe server. Each accepted connection answers
+// the subscription request and then hands the connection to serve.
+type standIn struct {
+	server        *httptest.Server
+	subscriptions int32
+}
+
+func newStandIn(t *testing.T, serve func(conn *websocket.Conn, connection int)) *standIn {
+	t.Helper()
+	s := &standIn{}
+	upgrader := websocket.Upgrader{}
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var req struct {
+			ID     uint64 `json:"id"`
+			Method string `json:"method"`
+		}
+		if err := conn.ReadJSON(&req); err != nil || req.Method != "logsSubscribe" {
+			return
+		}
+		connection := int(atomic.AddInt32(&s.subscriptions, 1))
+		if err := conn.WriteJSON(map[string]interface{}{"jsonrpc": "2.0", "result": connection, "id": req.ID}); err != nil {
+			return
+		}
+		serve(conn, connection)
+	}))
+	t.Cleanup(s.server.Close)
+	return s
+}
+
+func (s *standIn) url() string {
+	return "ws" + strings.TrimPrefix(s.server.URL, "http")
+}
+
+func notify(conn *websocket.Conn, subscription int, signature string, logs ...string) error {
+	return conn.WriteJSON(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "logsNotification",
+		"params": map[string]interface{}{
+			"subscription": subscription,
+			"result": map[string]interface{}{
+				"context": map[string]interface{}{"slot": 1},
+				"value": map[string]interface{}{
+					"signature": signature,
+					"err":       nil,
+					"logs":      logs,
+				},
+			},
+		},
+	})
+}
+
+func randomSignature(t *testing.T) string {
+	t.Helper()
+	var sig solana.Signature
+	if _, err := rand.Read(sig[:]); err != nil {
+		t.Fatal(err)
+	}
+	return sig.String()
+}
+
+func newTestListener(t *testing.T, url string) *Listener {
+	t.Helper()
+	l, err := New(url, raydiumProgramID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.MinBackoff = 10 * time.Millisecond
+	l.MaxBackoff = 50 * time.Millisecond
+	return l
+}
+
+func receive(t *testing.T, signatures <-chan string) string {
+	t.Helper()
+	select {
+	case sig, ok := <-signatures:
+		if !ok {
+			t.Fatal("signature channel closed unexpectedly")
+		}
+		return sig
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a signature")
+	}
+	return ""
+}
+
+func TestListenEmitsOnlyInitialize2(t *testing.T) {
+	swapSig := randomSignature(t)
+	poolSig := randomSignature(t)
+
+	server := newStandIn(t, func(conn *websocket.Conn, subscription int) {
+		if err := notify(conn, subscription, swapSig, "Program log: ray_log: swap"); err != nil {
+			return
+		}
+		if err := notify(conn, subscription, poolSig, "Program log: initialize2: InitializeInstruction2 { nonce: 254 }"); err != nil {
+			return
+		}
+		// Keep the connection open until the client goes away.
+		conn.ReadMessage()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	signatures := newTestListener(t, server.url()).Listen(ctx)
+
+	if got := receive(t, signatures); got != poolSig {
+		t.Fatalf("got signature %s, want %s", got, poolSig)
+	}
+
+	cancel()
+	select {
+	case _, ok := <-signatures:
+		if ok {
+			t.Fatal("unexpected signature after cancellation")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("signature channel not closed after cancellation")
+	}
+}
+
+func TestListenReconnectsAndResubscribes(t *testing.T) {
+	firstSig := randomSignature(t)
+	secondSig := randomSignature(t)
+
+	server := newStandIn(t, func(conn *websocket.Conn, subscription int) {
+		if subscription == 1 {
+			// Drop the socket right after the first event.
+			notify(conn, subscription, firstSig, "Program log: initialize2")
+			return
+		}
+		if err := notify(conn, subscription, secondSig, "Program log: initialize2"); err != nil {
+			return
+		}
+		conn.ReadMessage()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	signatures := newTestListener(t, server.url()).Listen(ctx)
+
+	if got := receive(t, signatures); got != firstSig {
+		t.Fatalf("got signature %s, want %s", got, firstSig)
+	}
+	if got := receive(t, signatures); got != secondSig {
+		t.Fatalf("got signature %s, want %s", got, secondSig)
+	}
+	if n := atomic.LoadInt32(&server.subscriptions); n != 2 {
+		t.Fatalf("got %d subscriptions, want 2", n)
+	}
+}
+
+func TestBackoffIsCapped(t *testing.T) {
+	l := &Listener{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second}
+	for attempt, w := range want {
+		if got := l.backoff(attempt); got != w {
+			t.Errorf("backoff(%d) = %v, want %v", attempt, got, w)
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package models
 
+import "github.com/low4ey/sniper/package/raydium"
+
 type MintsDataReponse struct {
-	TokenMint string `json:"tokenMint"`
-	SolMint   string `json:"solMint"`
+	TokenMint string        `json:"tokenMint"`
+	SolMint   string        `json:"solMint"`
+	Pool      *raydium.Pool `json:"pool"`    // The pool created by the transaction
+	PumpFun   bool          `json:"pumpFun"` // The token was launched on pump.fun
 }
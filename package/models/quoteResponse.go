@@ -1,5 +1,45 @@
 package models
 
+// QuoteResponse is a Jupiter v6 swap quote. Token amounts are in the smallest
+// unit of their mint.
 type QuoteResponse struct {
-	Data interface{} `json:"data"`
+	InputMint            string       `json:"inputMint"`
+	InAmount             uint64       `json:"inAmount,string"`
+	OutputMint           string       `json:"outputMint"`
+	OutAmount            uint64       `json:"outAmount,string"`
+	OtherAmountThreshold uint64       `json:"otherAmountThreshold,string"` // Minimum output after slippage (ExactIn)
+	SwapMode             string       `json:"swapMode"`
+	SlippageBps          int          `json:"slippageBps"`
+	PlatformFee          *PlatformFee `json:"platformFee"`
+	PriceImpactPct       float64      `json:"priceImpactPct,string"` // Fraction of the spot price lost to the trade, e.g. 0.01 = 1%
+	RoutePlan            []RoutePlan  `json:"routePlan"`
+	ContextSlot          int          `json:"contextSlot"`
+	TimeTaken            float64      `json:"timeTaken"`
+}
+
+type PlatformFee struct {
+	Amount uint64 `json:"amount,string"`
+	FeeBps int    `json:"feeBps"`
+}
+
+// RoutePlan is one hop of a quote's route.
+type RoutePlan struct {
+	SwapInfo SwapInfo `json:"swapInfo"`
+	Percent  int      `json:"percent"`
+}
+
+type SwapInfo struct {
+	AmmKey     string `json:"ammKey"`
+	Label      string `json:"label"` // AMM name, e.g. "Raydium"
+	InputMint  string `json:"inputMint"`
+	OutputMint string `json:"outputMint"`
+	InAmount   uint64 `json:"inAmount,string"`
+	OutAmount  uint64 `json:"outAmount,string"`
+	FeeAmount  uint64 `json:"feeAmount,string"`
+	FeeMint    string `json:"feeMint"`
+}
+
+// PriceImpactBps returns the quote's price impact in basis points.
+func (q *QuoteResponse) PriceImpactBps() float64 {
+	return q.PriceImpactPct * 10000
 }
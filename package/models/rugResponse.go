@@ -19,45 +19,51 @@ type RugResponse struct {
 		Mutable         bool   `json:"mutable"`
 		UpdateAuthority string `json:"updateAuthority"`
 	} `json:"tokenMeta"`
-	TopHolders []struct {
-		Address        string `json:"address"`
-		Amount         int    `json:"amount"`
-		Decimals       int    `json:"decimals"`
-		Pct            int    `json:"pct"`
-		UiAmount       int    `json:"uiAmount"`
-		UiAmountString string `json:"uiAmountString"`
-		Owner          string `json:"owner"`
-		Insider        bool   `json:"insider"`
-	} `json:"topHolders"`
+	TopHolders      []Holder    `json:"topHolders"`
 	FreezeAuthority interface{} `json:"freezeAuthority"`
 	MintAuthority   interface{} `json:"mintAuthority"`
-	Risks           []struct {
-		Name        string `json:"name"`
-		Value       string `json:"value"`
-		Description string `json:"description"`
-		Score       int    `json:"score"`
-		Level       string `json:"level"`
-	} `json:"risks"`
-	Score    int `json:"score"`
-	FileMeta struct {
+	Risks           []Risk      `json:"risks"`
+	Score           int         `json:"score"`
+	FileMeta        struct {
 		Description string `json:"description"`
 		Name        string `json:"name"`
 		Symbol      string `json:"symbol"`
 		Image       string `json:"image"`
 	} `json:"fileMeta"`
-	LockerOwners map[string]interface{} `json:"lockerOwners"`
-	Lockers      map[string]interface{} `json:"lockers"`
-	LpLockers    interface{}            `json:"lpLockers"`
-	Markets      []struct {
-		Pubkey     string `json:"pubkey"`
-		MarketType string `json:"marketType"`
-		MintA      string `json:"mintA"`
-		MintB      string `json:"mintB"`
-		MintLP     string `json:"mintLP"`
-		LiquidityA string `json:"liquidityA"`
-		LiquidityB string `json:"liquidityB"`
-	} `json:"markets"`
-	TotalMarketLiquidity int  `json:"totalMarketLiquidity"`
-	TotalLPProviders     int  `json:"totalLPProviders"`
-	Rugged               bool `json:"rugged"`
+	LockerOwners         map[string]interface{} `json:"lockerOwners"`
+	Lockers              map[string]interface{} `json:"lockers"`
+	LpLockers            interface{}            `json:"lpLockers"`
+	Markets              []Market               `json:"markets"`
+	TotalMarketLiquidity float64                `json:"totalMarketLiquidity"`
+	TotalLPProviders     int                    `json:"totalLPProviders"`
+	Rugged               bool                   `json:"rugged"`
+}
+
+type Holder struct {
+	Address        string  `json:"address"`
+	Amount         float64 `json:"amount"`
+	Decimals       int     `json:"decimals"`
+	Pct            float64 `json:"pct"`
+	UiAmount       float64 `json:"uiAmount"`
+	UiAmountString string  `json:"uiAmountString"`
+	Owner          string  `json:"owner"`
+	Insider        bool    `json:"insider"`
+}
+
+type Risk struct {
+	Name        string `json:"name"`
+	Value       string `json:"value"`
+	Description string `json:"description"`
+	Score       int    `json:"score"`
+	Level       string `json:"level"`
+}
+
+type Market struct {
+	Pubkey     string `json:"pubkey"`
+	MarketType string `json:"marketType"`
+	MintA      string `json:"mintA"`
+	MintB      string `json:"mintB"`
+	MintLP     string `json:"mintLP"`
+	LiquidityA string `json:"liquidityA"`
+	LiquidityB string `json:"liquidityB"`
 }
@@ -17,5 +17,12 @@ type SerializedQuoteResponse struct {
 		CategoryName                 string `json:"categoryName"`
 		HeuristicMaxSlippageBps      int    `json:"heuristicMaxSlippageBps"`
 	} `json:"dynamicSlippageReport"`
-	SimulationError string `json:"simulationError"`
+	SimulationError *SwapSimulationError `json:"simulationError"`
+}
+
+// SwapSimulationError is reported by the swap API when its own simulation of
+// the transaction failed.
+type SwapSimulationError struct {
+	ErrorCode string `json:"errorCode"`
+	Error     string `json:"error"`
 }
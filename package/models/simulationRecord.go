@@ -0,0 +1,12 @@
+package models
+
+type SimulationRecord struct {
+	ID            *int   `json:"id,omitempty"`
+	Time          int    `json:"time"`
+	Signature     string `json:"signature"`
+	Token         string `json:"token"`
+	Side          string `json:"side"` // "buy" or "sell"
+	UnitsConsumed int    `json:"unitsConsumed"`
+	Err           string `json:"err,omitempty"` // empty when the simulation succeeded
+	Logs          string `json:"logs"`          // newline separated program logs
+}
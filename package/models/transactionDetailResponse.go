@@ -12,23 +12,15 @@ type Instructions struct {
 }
 
 type TransactionDetailResponse struct {
-	Description    string `json:"description"`
-	Type           string `json:"type"`
-	Source         string `json:"source"`
-	Fee            int    `json:"fee"`
-	FeePayer       string `json:"feePayer"`
-	Signature      string `json:"signature"`
-	Slot           int    `json:"slot"`
-	Timestamp      int    `json:"timestamp"`
-	TokenTransfers []struct {
-		FromTokenAccount string `json:"fromTokenAccount"`
-		ToTokenAccount   string `json:"toTokenAccount"`
-		FromUserAccount  string `json:"fromUserAccount"`
-		ToUserAccount    string `json:"toUserAccount"`
-		TokenAmount      string `json:"tokenAmount"`
-		Mint             string `json:"mint"`
-		TokenStandard    string `json:"tokenStandard"`
-	} `json:"tokenTransfers"`
+	Description     string          `json:"description"`
+	Type            string          `json:"type"`
+	Source          string          `json:"source"`
+	Fee             int             `json:"fee"`
+	FeePayer        string          `json:"feePayer"`
+	Signature       string          `json:"signature"`
+	Slot            int             `json:"slot"`
+	Timestamp       int             `json:"timestamp"`
+	TokenTransfers  []TokenTransfer `json:"tokenTransfers"`
 	NativeTransfers []struct {
 		FromUserAccount string `json:"fromUserAccount"`
 		ToUserAccount   string `json:"toUserAccount"`
@@ -91,25 +83,10 @@ type TransactionDetailResponse struct {
 				Mint string `json:"mint"`
 			} `json:"tokenFees"`
 			InnerSwaps []struct {
-				TokenInputs []struct {
-					FromTokenAccount string `json:"fromTokenAccount"`
-					ToTokenAccount   string `json:"toTokenAccount"`
-					FromUserAccount  string `json:"fromUserAccount"`
-					ToUserAccount    string `json:"toUserAccount"`
-					TokenAmount      int    `json:"tokenAmount"`
-					Mint             string `json:"mint"`
-					TokenStandard    string `json:"tokenStandard"`
-				} `json:"tokenInputs"`
-				TokenOutputs []struct {
-					FromTokenAccount string `json:"fromTokenAccount"`
-					ToTokenAccount   string `json:"toTokenAccount"`
-					FromUserAccount  string `json:"fromUserAccount"`
-					ToUserAccount    string `json:"toUserAccount"`
-					TokenAmount      int    `json:"tokenAmount"`
-					Mint             string `json:"mint"`
-					TokenStandard    string `json:"tokenStandard"`
-				} `json:"tokenOutputs"`
-				TokenFees []struct {
+				ProgramInfo  *ProgramInfo    `json:"programInfo"`
+				TokenInputs  []TokenTransfer `json:"tokenInputs"`
+				TokenOutputs []TokenTransfer `json:"tokenOutputs"`
+				TokenFees    []struct {
 					UserAccount    string `json:"userAccount"`
 					TokenAccount   string `json:"tokenAccount"`
 					RawTokenAmount struct {
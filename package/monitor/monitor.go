@@ -0,0 +1,193 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/low4ey/sniper/internal/config"
+	"github.com/low4ey/sniper/package/models"
+	"github.com/low4ey/sniper/package/price"
+	"github.com/low4ey/sniper/package/tracker/db"
+)
+
+// Seller is the part of the transactions client the monitor needs.
+type Seller interface {
+	TokenBalance(ctx context.Context, tokenMint string) (uint64, error)
+	CreateSellTransaction(ctx context.Context, solMint, tokenMint, amount string) (*models.CreateSellTransactionResponse, error)
+}
+
+// Monitor periodically prices every holding and sells it once the stop loss or
+// take profit configured in SellConfig is crossed.
+type Monitor struct {
+	seller  Seller
+	sources map[string]price.Source // keyed by Source.Name
+
+	mu      sync.Mutex
+	selling map[string]bool // token mints with a sell in flight
+	wg      sync.WaitGroup
+}
+
+// New creates a Monitor selling through seller. Holdings are priced by the
+// sources named in SellConfig.PriceSource and SellConfig.PriceFallbacks.
+func New(seller Seller, sources ...price.Source) *Monitor {
+	m := &Monitor{
+		seller:  seller,
+		sources: make(map[string]price.Source, len(sources)),
+		selling: make(map[string]bool),
+	}
+	for _, source := range sources {
+		m.sources[source.Name()] = source
+	}
+	return m
+}
+
+// Run checks holdings every SellConfig.PriceCheckInterval until ctx is done,
+// then waits for in-flight sells to finish. Checks and sells run on work, so a
+// sell already sent is still recorded after ctx is done.
+func (m *Monitor) Run(ctx, work context.Context) {
+	defer m.wg.Wait()
+	for {
+		timer := time.NewTimer(time.Duration(config.Get().Sell.PriceCheckInterval) * time.Millisecond)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		m.check(work)
+	}
+}
+
+func (m *Monitor) check(ctx context.Context) {
+	cfg := config.Get()
+	if !cfg.Sell.AutoSell {
+		return
+	}
+	source, err := m.chain(cfg.Sell)
+	if err != nil {
+		log.Printf("⛔ Auto sell: %v", err)
+		return
+	}
+
+	selectHoldings := db.SelectAllHoldings
+	if cfg.RugCheck.SimulationMode {
+		selectHoldings = db.SelectOpenPaperHoldings
+	}
+	holdings, err := selectHoldings()
+	if err != nil {
+		log.Printf("⛔ Auto sell: could not read holdings: %v", err)
+		return
+	}
+
+	var candidates []models.HoldingRecord
+	for _, holding := range holdings {
+		if holding.PerTokenPaidUSDC <= 0 || !m.lock(holding.Token) {
+			continue
+		}
+		candidates = append(candidates, holding)
+	}
+	if len(candidates) == 0 {
+		return
+	}
+
+	mints := make([]string, len(candidates))
+	for i, holding := range candidates {
+		mints[i] = holding.Token
+	}
+	quotes, err := source.Prices(ctx, mints)
+	if err != nil {
+		log.Printf("⚠️ Auto sell: some holdings could not be priced: %v", err)
+	}
+
+	for _, holding := range candidates {
+		quote, ok := quotes[holding.Token]
+		if !ok {
+			m.unlock(holding.Token)
+			continue
+		}
+
+		pnlPercent := (quote.PriceUSD - holding.PerTokenPaidUSDC) / holding.PerTokenPaidUSDC * 100
+		reason := sellReason(cfg.Sell, pnlPercent)
+		if reason == "" {
+			m.unlock(holding.Token)
+			continue
+		}
+
+		log.Printf("📉 Auto sell: %s (%s) at %.2f%% PnL (%s price), %s reached", holding.TokenName, holding.Token, pnlPercent, quote.Source, reason)
+		m.wg.Add(1)
+		go func(holding models.HoldingRecord) {
+			defer m.wg.Done()
+			defer m.unlock(holding.Token)
+			m.sell(ctx, cfg.LiquidityPool.WsolPcMint, holding, cfg.RugCheck.SimulationMode)
+		}(holding)
+	}
+}
+
+// chain builds the composite price source configured in sell.
+func (m *Monitor) chain(sell config.SellConfig) (*price.Composite, error) {
+	names := append([]string{sell.PriceSource}, sell.PriceFallbacks...)
+	sources := make([]price.Source, 0, len(names))
+	for _, name := range names {
+		source, ok := m.sources[name]
+		if !ok {
+			return nil, fmt.Errorf("price source %q is not available", name)
+		}
+		sources = append(sources, source)
+	}
+	return price.NewComposite(sources, float64(sell.MaxPriceDeviation), time.Duration(sell.MaxPriceAge)*time.Millisecond), nil
+}
+
+func (m *Monitor) sell(ctx context.Context, solMint string, holding models.HoldingRecord, simulated bool) {
+	balance, err := m.seller.TokenBalance(ctx, holding.Token)
+	if err != nil {
+		log.Printf("⛔ Auto sell: could not fetch balance of %s: %v", holding.Token, err)
+		return
+	}
+	resp, err := m.seller.CreateSellTransaction(ctx, solMint, holding.Token, strconv.FormatUint(balance, 10))
+	if err != nil {
+		log.Printf("⛔ Auto sell of %s failed: %v", holding.Token, err)
+		return
+	}
+	if !resp.Success {
+		log.Printf("⛔ Auto sell of %s failed: %s", holding.Token, resp.Msg)
+		return
+	}
+	if simulated {
+		log.Printf("✅ Auto sell of %s recorded as a paper trade", holding.Token)
+		return
+	}
+	log.Printf("✅ Auto sell of %s confirmed: https://solscan.io/tx/%s", holding.Token, resp.Tx)
+}
+
+// sellReason returns which threshold pnlPercent crosses, or "" if none. A
+// threshold of 0 disables it.
+func sellReason(sell config.SellConfig, pnlPercent float64) string {
+	if sell.StopLossPercent > 0 && pnlPercent <= -float64(sell.StopLossPercent) {
+		return "stop loss"
+	}
+	if sell.TakeProfitPercent > 0 && pnlPercent >= float64(sell.TakeProfitPercent) {
+		return "take profit"
+	}
+	return ""
+}
+
+// lock marks a token as being sold, reporting false if it already is.
+func (m *Monitor) lock(token string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.selling[token] {
+		return false
+	}
+	m.selling[token] = true
+	return true
+}
+
+func (m *Monitor) unlock(token string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.selling, token)
+}
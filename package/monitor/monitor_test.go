@@ -0,0 +1,181 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/low4ey/sniper/internal/config"
+	"github.com/low4ey/sniper/package/models"
+	"github.com/low4ey/sniper/package/price"
+	"github.com/low4ey/sniper/package/tracker/db"
+)
+
+const testToken = "TestTokenMint11111111111111111111111111111"
+
+type fakeSeller struct {
+	mu      sync.Mutex
+	sells   int
+	err     error
+	started chan struct{} // receives once per sell, if set
+	release chan struct{} // sells wait for it to be closed, if set
+}
+
+func (f *fakeSeller) TokenBalance(ctx context.Context, tokenMint string) (uint64, error) {
+	return 1000, nil
+}
+
+func (f *fakeSeller) CreateSellTransaction(ctx context.Context, solMint, tokenMint, amount string) (*models.CreateSellTransactionResponse, error) {
+	f.mu.Lock()
+	f.sells++
+	f.mu.Unlock()
+	if f.started != nil {
+		f.started <- struct{}{}
+	}
+	if f.release != nil {
+		<-f.release
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &models.CreateSellTransactionResponse{Success: true, Tx: "sig"}, nil
+}
+
+func (f *fakeSeller) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.sells
+}
+
+type stubSource struct {
+	prices map[string]float64
+}
+
+func (s *stubSource) Name() string { return "dex" }
+
+func (s *stubSource) Price(ctx context.Context, mint string) (price.Quote, error) {
+	p, ok := s.prices[mint]
+	if !ok {
+		return price.Quote{}, errors.New("no price")
+	}
+	return price.Quote{Mint: mint, PriceUSD: p, Source: s.Name(), At: time.Now()}, nil
+}
+
+// setup activates a config selling at a 10% stop loss or 100% take profit on
+// the stub source alone, and a temporary database holding testToken bought at
+// 1 USD per token.
+func setup(t *testing.T) {
+	t.Helper()
+	cfg := config.ConfigVal
+	cfg.Sell.AutoSell = true
+	cfg.Sell.PriceSource = "dex"
+	cfg.Sell.PriceFallbacks = nil
+	cfg.Sell.StopLossPercent = 10
+	cfg.Sell.TakeProfitPercent = 100
+	cfg.RugCheck.SimulationMode = false
+	config.Set(&cfg)
+	t.Cleanup(func() { config.Set(nil) })
+
+	if err := db.Open(filepath.Join(t.TempDir(), "holdings.db")); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := db.InsertHolding(models.HoldingRecord{Token: testToken, TokenName: "Test", Balance: 1000, PerTokenPaidUSDC: 1}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (m *Monitor) locked(token string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.selling[token]
+}
+
+func TestSellReason(t *testing.T) {
+	sell := config.SellConfig{StopLossPercent: 10, TakeProfitPercent: 100}
+	tests := []struct {
+		name string
+		sell config.SellConfig
+		pnl  float64
+		want string
+	}{
+		{"stop loss reached", sell, -10, "stop loss"},
+		{"below stop loss", sell, -50, "stop loss"},
+		{"above stop loss", sell, -9.99, ""},
+		{"take profit reached", sell, 100, "take profit"},
+		{"below take profit", sell, 99.99, ""},
+		{"stop loss disabled", config.SellConfig{TakeProfitPercent: 100}, -90, ""},
+		{"take profit disabled", config.SellConfig{StopLossPercent: 10}, 500, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sellReason(tt.sell, tt.pnl); got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckSellsHoldingOnce(t *testing.T) {
+	setup(t)
+	seller := &fakeSeller{started: make(chan struct{}, 2), release: make(chan struct{})}
+	m := New(seller, &stubSource{prices: map[string]float64{testToken: 0.5}})
+	ctx := context.Background()
+
+	m.check(ctx)
+	<-seller.started
+	m.check(ctx) // the first sell is still in flight
+	if got := seller.count(); got != 1 {
+		t.Fatalf("started %d sells, want 1 while the first is in flight", got)
+	}
+	if !m.locked(testToken) {
+		t.Fatal("holding not locked during its sell")
+	}
+
+	close(seller.release)
+	m.wg.Wait()
+	if m.locked(testToken) {
+		t.Fatal("holding still locked after its sell finished")
+	}
+}
+
+func TestCheckUnlocksWithoutPrice(t *testing.T) {
+	setup(t)
+	seller := &fakeSeller{}
+	source := &stubSource{prices: map[string]float64{}}
+	m := New(seller, source)
+
+	m.check(context.Background())
+	m.wg.Wait()
+	if seller.count() != 0 || m.locked(testToken) {
+		t.Fatalf("got %d sells and locked %v without a price, want none and unlocked", seller.count(), m.locked(testToken))
+	}
+
+	source.prices[testToken] = 1.5 // within both thresholds
+	m.check(context.Background())
+	m.wg.Wait()
+	if seller.count() != 0 || m.locked(testToken) {
+		t.Fatalf("got %d sells and locked %v between thresholds, want none and unlocked", seller.count(), m.locked(testToken))
+	}
+}
+
+func TestCheckUnlocksFailedSell(t *testing.T) {
+	setup(t)
+	seller := &fakeSeller{err: errors.New("swap failed")}
+	m := New(seller, &stubSource{prices: map[string]float64{testToken: 2}})
+
+	m.check(context.Background())
+	m.wg.Wait()
+	if m.locked(testToken) {
+		t.Fatal("holding still locked after a failed sell")
+	}
+
+	m.check(context.Background())
+	m.wg.Wait()
+	if got := seller.count(); got != 2 {
+		t.Fatalf("got %d sell attempts, want a retry on the next check", got)
+	}
+}
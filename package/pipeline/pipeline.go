@@ -0,0 +1,272 @@
+package pipeline
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// seenLimit bounds how many recent signatures are remembered to drop
+// duplicates reported by more than one source.
+const seenLimit = 10000
+
+// Handler processes one pool creation signature.
+type Handler func(ctx context.Context, signature string)
+
+// Options configures a Pipeline.
+type Options struct {
+	Workers   int           // Signatures processed concurrently
+	QueueSize int           // Signatures waiting for a worker, further ones are dropped
+	MaxAge    time.Duration // Queued signatures older than this are skipped, 0 for no limit
+}
+
+// Source is a stream of pool creation signatures. Signatures of a Priority
+// source are queued ahead of those of other sources.
+type Source struct {
+	Name       string
+	Signatures <-chan string
+	Priority   bool
+}
+
+// Stats counts the signatures seen by a Pipeline.
+type Stats struct {
+	Depth      int // Signatures currently waiting for a worker
+	InFlight   int // Signatures currently being processed
+	Accepted   int // Signatures queued
+	Duplicates int // Signatures ignored because they were already queued
+	Dropped    int // Signatures dropped because the queue was full
+	Expired    int // Signatures skipped because they waited longer than MaxAge
+	Processed  int // Signatures handed to the handler and finished
+	Scheduled  int // Tasks currently waiting for their delay before being queued
+}
+
+type job struct {
+	signature string
+	queuedAt  time.Time
+	task      func(ctx context.Context) // run instead of the handler, nil for signatures
+}
+
+// Pipeline queues pool creation signatures and runs them through a handler
+// on a fixed number of workers, so bursts of new pools cannot start an
+// unbounded number of buys at once.
+type Pipeline struct {
+	handler Handler
+	opts    Options
+
+	mu       sync.Mutex
+	ready    *sync.Cond
+	queue    []job // priority jobs first
+	priority int   // number of priority jobs at the front of queue
+	closed   bool
+	seen     map[string]bool
+	seenFIFO []string
+	timers   map[*time.Timer]bool // Schedule timers that have not fired yet
+	stats    Stats
+}
+
+// New creates a Pipeline. Workers and QueueSize are raised to 1 if smaller.
+func New(handler Handler, opts Options) *Pipeline {
+	if opts.Workers < 1 {
+		opts.Workers = 1
+	}
+	if opts.QueueSize < 1 {
+		opts.QueueSize = 1
+	}
+	p := &Pipeline{
+		handler: handler,
+		opts:    opts,
+		seen:    make(map[string]bool),
+		timers:  make(map[*time.Timer]bool),
+	}
+	p.ready = sync.NewCond(&p.mu)
+	return p
+}
+
+// Stats returns a snapshot of the pipeline counters.
+func (p *Pipeline) Stats() Stats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	stats := p.stats
+	stats.Depth = len(p.queue)
+	stats.Scheduled = len(p.timers)
+	return stats
+}
+
+// Run feeds the signatures of every source to the workers until all source
+// channels are closed, then waits for the workers to finish. Signatures still
+// queued once ctx is done are discarded. Handlers are passed work instead of
+// ctx, so a buy already started can outlive ctx and still be recorded.
+func (p *Pipeline) Run(ctx, work context.Context, sources ...Source) {
+	var workers sync.WaitGroup
+	for i := 0; i < p.opts.Workers; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			p.work(ctx, work)
+		}()
+	}
+
+	var feeders sync.WaitGroup
+	for _, source := range sources {
+		feeders.Add(1)
+		go func(source Source) {
+			defer feeders.Done()
+			for signature := range source.Signatures {
+				p.enqueue(source, signature)
+			}
+		}(source)
+	}
+	feeders.Wait()
+
+	p.mu.Lock()
+	p.closed = true
+	for timer := range p.timers {
+		timer.Stop()
+		log.Printf("🚫 Dropping a scheduled task, the pipeline is closing")
+	}
+	p.timers = nil
+	p.ready.Broadcast()
+	p.mu.Unlock()
+	workers.Wait()
+}
+
+// Schedule queues task for signature in the priority lane once delay has
+// passed, without holding a worker meanwhile. Tasks still waiting when the
+// sources are exhausted are dropped.
+func (p *Pipeline) Schedule(delay time.Duration, signature string, task func(ctx context.Context)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(delay, func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if !p.timers[timer] {
+			return
+		}
+		delete(p.timers, timer)
+		p.insertPriority(job{signature: signature, queuedAt: time.Now(), task: task})
+		p.ready.Signal()
+	})
+	p.timers[timer] = true
+}
+
+func (p *Pipeline) enqueue(source Source, signature string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.seen[signature] {
+		p.stats.Duplicates++
+		if source.Priority {
+			p.promote(signature, source)
+		}
+		return
+	}
+	if len(p.queue) >= p.opts.QueueSize {
+		p.stats.Dropped++
+		log.Printf("⚠️ Queue full (%d waiting), dropping %s from %s", len(p.queue), signature, source.Name)
+		return
+	}
+	p.remember(signature)
+
+	j := job{signature: signature, queuedAt: time.Now()}
+	if source.Priority {
+		p.insertPriority(j)
+	} else {
+		p.queue = append(p.queue, j)
+	}
+	p.stats.Accepted++
+	log.Printf("📥 Queued %s from %s (%d waiting)", signature, source.Name, len(p.queue))
+	p.ready.Signal()
+}
+
+// insertPriority queues j behind the jobs already in the priority lane.
+func (p *Pipeline) insertPriority(j job) {
+	p.queue = append(p.queue, job{})
+	copy(p.queue[p.priority+1:], p.queue[p.priority:])
+	p.queue[p.priority] = j
+	p.priority++
+}
+
+// promote moves signature to the priority lane if it is still waiting in the
+// normal lane, so a priority source reporting it after another source does
+// not lose its priority.
+func (p *Pipeline) promote(signature string, source Source) {
+	for i := p.priority; i < len(p.queue); i++ {
+		if p.queue[i].signature != signature {
+			continue
+		}
+		j := p.queue[i]
+		p.queue = append(p.queue[:i], p.queue[i+1:]...)
+		p.insertPriority(j)
+		log.Printf("⏫ Prioritized %s from %s", signature, source.Name)
+		return
+	}
+}
+
+// remember records signature as seen, forgetting the oldest signature once
+// seenLimit is reached.
+func (p *Pipeline) remember(signature string) {
+	if len(p.seenFIFO) >= seenLimit {
+		delete(p.seen, p.seenFIFO[0])
+		p.seenFIFO = p.seenFIFO[1:]
+	}
+	p.seen[signature] = true
+	p.seenFIFO = append(p.seenFIFO, signature)
+}
+
+// next blocks until a job is queued and returns it, or returns false once the
+// pipeline is closed and drained.
+func (p *Pipeline) next() (job, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for len(p.queue) == 0 && !p.closed {
+		p.ready.Wait()
+	}
+	if len(p.queue) == 0 {
+		return job{}, false
+	}
+	j := p.queue[0]
+	p.queue = p.queue[1:]
+	if p.priority > 0 {
+		p.priority--
+	}
+	return j, true
+}
+
+func (p *Pipeline) work(ctx, work context.Context) {
+	for {
+		job, ok := p.next()
+		if !ok {
+			return
+		}
+		if ctx.Err() != nil {
+			continue
+		}
+		if age := time.Since(job.queuedAt); p.opts.MaxAge > 0 && age > p.opts.MaxAge {
+			p.count(func(s *Stats) { s.Expired++ })
+			log.Printf("⚠️ Skipping %s, it waited %v in the queue", job.signature, age.Round(time.Millisecond))
+			continue
+		}
+
+		p.count(func(s *Stats) { s.InFlight++ })
+		if job.task != nil {
+			job.task(work)
+			p.count(func(s *Stats) { s.InFlight-- })
+			continue
+		}
+		p.handler(work, job.signature)
+		p.count(func(s *Stats) {
+			s.InFlight--
+			s.Processed++
+		})
+	}
+}
+
+func (p *Pipeline) count(update func(*Stats)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	update(&p.stats)
+}
@@ -0,0 +1,243 @@
+package pipeline
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// blockingHandler reports every signature it starts on started and returns
+// once release is closed.
+func blockingHandler(started chan<- string, release <-chan struct{}) Handler {
+	return func(ctx context.Context, signature string) {
+		started <- signature
+		<-release
+	}
+}
+
+func TestPipelineLimitsWorkersAndDropsWhenFull(t *testing.T) {
+	started := make(chan string, 10)
+	release := make(chan struct{})
+	p := New(blockingHandler(started, release), Options{Workers: 2, QueueSize: 1})
+
+	signatures := make(chan string)
+	done := make(chan struct{})
+	go func() {
+		p.Run(context.Background(), context.Background(), Source{Name: "test", Signatures: signatures})
+		close(done)
+	}()
+
+	signatures <- "a"
+	<-started
+	signatures <- "b"
+	<-started
+	signatures <- "c" // waits for a worker
+	signatures <- "d" // queue full
+	select {
+	case sig := <-started:
+		t.Fatalf("%s started while both workers were busy", sig)
+	case <-time.After(20 * time.Millisecond):
+	}
+	if stats := p.Stats(); stats.InFlight != 2 || stats.Depth != 1 {
+		t.Fatalf("got %d in flight and %d queued, want 2 and 1", stats.InFlight, stats.Depth)
+	}
+
+	close(release)
+	close(signatures)
+	<-done
+
+	stats := p.Stats()
+	if stats.Accepted != 3 || stats.Dropped != 1 || stats.Processed != 3 || stats.Depth != 0 || stats.InFlight != 0 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestPipelineSkipsExpiredSignatures(t *testing.T) {
+	started := make(chan string, 10)
+	release := make(chan struct{})
+	p := New(blockingHandler(started, release), Options{Workers: 1, QueueSize: 10, MaxAge: 10 * time.Millisecond})
+
+	signatures := make(chan string)
+	done := make(chan struct{})
+	go func() {
+		p.Run(context.Background(), context.Background(), Source{Name: "test", Signatures: signatures})
+		close(done)
+	}()
+
+	signatures <- "a"
+	<-started
+	signatures <- "b"
+	time.Sleep(30 * time.Millisecond)
+	close(release)
+	close(signatures)
+	<-done
+
+	stats := p.Stats()
+	if stats.Processed != 1 || stats.Expired != 1 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestPipelineDiscardsQueueOnCancel(t *testing.T) {
+	var mu sync.Mutex
+	var handled []string
+	var handlerErr error
+	ctx, cancel := context.WithCancel(context.Background())
+	p := New(func(work context.Context, signature string) {
+		cancel()
+		mu.Lock()
+		handled = append(handled, signature)
+		handlerErr = work.Err()
+		mu.Unlock()
+	}, Options{Workers: 1, QueueSize: 10})
+
+	signatures := make(chan string, 3)
+	signatures <- "a"
+	signatures <- "b"
+	signatures <- "c"
+	close(signatures)
+	p.Run(ctx, context.Background(), Source{Name: "test", Signatures: signatures})
+
+	if len(handled) != 1 {
+		t.Fatalf("handled %v after cancel, want only the first signature", handled)
+	}
+	if handlerErr != nil {
+		t.Fatalf("running handler saw %v, want its work context to outlive the cancel", handlerErr)
+	}
+}
+
+// waitFor polls cond until it holds or a second has passed.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestPipelinePrioritizesAndDeduplicates(t *testing.T) {
+	var mu sync.Mutex
+	var order []string
+	started := make(chan string, 10)
+	release := make(chan struct{})
+	p := New(func(ctx context.Context, signature string) {
+		mu.Lock()
+		order = append(order, signature)
+		mu.Unlock()
+		started <- signature
+		<-release
+	}, Options{Workers: 1, QueueSize: 10})
+
+	normal, priority := make(chan string), make(chan string)
+	done := make(chan struct{})
+	go func() {
+		p.Run(context.Background(), context.Background(), Source{Name: "normal", Signatures: normal}, Source{Name: "priority", Signatures: priority, Priority: true})
+		close(done)
+	}()
+
+	normal <- "a"
+	<-started
+	normal <- "b"
+	normal <- "c"
+	waitFor(t, func() bool { return p.Stats().Accepted == 3 })
+	priority <- "p1"
+	priority <- "p2"
+	priority <- "c" // reported by both sources, moves to the priority lane
+	waitFor(t, func() bool { return p.Stats().Duplicates == 1 })
+
+	close(release)
+	close(normal)
+	close(priority)
+	<-done
+
+	want := []string{"a", "p1", "p2", "c", "b"}
+	if len(order) != len(want) {
+		t.Fatalf("processed %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("processed %v, want %v", order, want)
+		}
+	}
+	if stats := p.Stats(); stats.Accepted != 5 || stats.Duplicates != 1 || stats.Processed != 5 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestPipelineScheduleFreesTheWorker(t *testing.T) {
+	var mu sync.Mutex
+	var order []string
+	record := func(name string) {
+		mu.Lock()
+		order = append(order, name)
+		mu.Unlock()
+	}
+	var p *Pipeline
+	p = New(func(ctx context.Context, signature string) {
+		record(signature)
+		switch signature {
+		case "a":
+			p.Schedule(30*time.Millisecond, signature, func(ctx context.Context) { record("buy a") })
+		case "b":
+			p.Schedule(time.Hour, signature, func(ctx context.Context) { record("buy b") })
+		}
+	}, Options{Workers: 1, QueueSize: 10})
+
+	signatures := make(chan string)
+	done := make(chan struct{})
+	go func() {
+		p.Run(context.Background(), context.Background(), Source{Name: "test", Signatures: signatures})
+		close(done)
+	}()
+
+	signatures <- "a"
+	signatures <- "b"
+	waitFor(t, func() bool { return p.Stats().Processed == 2 })
+	if stats := p.Stats(); stats.Scheduled != 2 {
+		t.Fatalf("got %d scheduled tasks, want 2", stats.Scheduled)
+	}
+	waitFor(t, func() bool { return p.Stats().Scheduled == 1 && p.Stats().InFlight == 0 })
+	close(signatures)
+	<-done
+
+	want := []string{"a", "b", "buy a"}
+	if len(order) != len(want) {
+		t.Fatalf("ran %v, want %v with the task still waiting dropped", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("ran %v, want %v", order, want)
+		}
+	}
+}
+
+func TestPipelineProcessesSignatureFromBothListenersOnce(t *testing.T) {
+	var mu sync.Mutex
+	handled := make(map[string]int)
+	p := New(func(ctx context.Context, signature string) {
+		mu.Lock()
+		handled[signature]++
+		mu.Unlock()
+	}, Options{Workers: 2, QueueSize: 10})
+
+	amm, migrations := make(chan string, 2), make(chan string, 2)
+	amm <- "migrated"
+	amm <- "amm only"
+	migrations <- "migrated"
+	close(amm)
+	close(migrations)
+	p.Run(context.Background(), context.Background(),
+		Source{Name: "amm_v4", Signatures: amm},
+		Source{Name: "pump.fun", Signatures: migrations, Priority: true})
+
+	if handled["migrated"] != 1 || handled["amm only"] != 1 {
+		t.Fatalf("handled %v, want every signature once", handled)
+	}
+	if stats := p.Stats(); stats.Accepted != 2 || stats.Duplicates != 1 || stats.Processed != 2 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
@@ -0,0 +1,153 @@
+package price
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/low4ey/sniper/package/models"
+)
+
+// DexQuote is the price of a token on its most liquid Solana pair.
+type DexQuote struct {
+	Mint         string
+	PairAddress  string
+	DexID        string
+	PriceUSD     float64
+	PriceNative  float64
+	LiquidityUSD float64
+	VolumeM5     float64
+	FetchedAt    time.Time
+}
+
+// Dexscreener prices tokens with the Dexscreener token pairs API
+// (DEX_HTTPS_TOKEN_PAIRS). Quotes are cached for a short time so frequent
+// polling stays under the API rate limit.
+type Dexscreener struct {
+	baseURL  string
+	client   *http.Client
+	cacheTTL time.Duration
+
+	mu    sync.Mutex
+	cache map[string]*DexQuote
+}
+
+// NewDexscreener creates a Dexscreener source for the tokens endpoint at
+// baseURL, e.g. https://api.dexscreener.com/latest/dex/tokens.
+func NewDexscreener(baseURL string, client *http.Client, cacheTTL time.Duration) *Dexscreener {
+	return &Dexscreener{
+		baseURL:  strings.TrimSuffix(baseURL, "/"),
+		client:   client,
+		cacheTTL: cacheTTL,
+		cache:    make(map[string]*DexQuote),
+	}
+}
+
+// Name identifies the source as "dex".
+func (d *Dexscreener) Name() string { return "dex" }
+
+// Price returns the USD price of mint, observed when its quote was fetched.
+func (d *Dexscreener) Price(ctx context.Context, mint string) (Quote, error) {
+	quote, err := d.Quote(ctx, mint)
+	if err != nil {
+		return Quote{}, err
+	}
+	return Quote{Mint: mint, PriceUSD: quote.PriceUSD, Source: d.Name(), At: quote.FetchedAt}, nil
+}
+
+// PriceUSD returns the USD price of mint.
+func (d *Dexscreener) PriceUSD(ctx context.Context, mint string) (float64, error) {
+	quote, err := d.Quote(ctx, mint)
+	if err != nil {
+		return 0, err
+	}
+	return quote.PriceUSD, nil
+}
+
+// Quote returns the price, liquidity and 5 minute volume of mint on its most
+// liquid Solana pair.
+func (d *Dexscreener) Quote(ctx context.Context, mint string) (*DexQuote, error) {
+	d.mu.Lock()
+	cached, ok := d.cache[mint]
+	d.mu.Unlock()
+	if ok && time.Since(cached.FetchedAt) < d.cacheTTL {
+		return cached, nil
+	}
+
+	pairs, err := d.fetchPairs(ctx, mint)
+	if err != nil {
+		return nil, err
+	}
+	pair := mostLiquidSolanaPair(pairs, mint)
+	if pair == nil {
+		return nil, fmt.Errorf("no Solana pair found for %s", mint)
+	}
+
+	priceUSD, err := strconv.ParseFloat(pair.PriceUSD, 64)
+	if err != nil || priceUSD <= 0 {
+		return nil, fmt.Errorf("invalid USD price %q for pair %s", pair.PriceUSD, pair.PairAddress)
+	}
+	priceNative, _ := strconv.ParseFloat(pair.PriceNative, 64)
+
+	quote := &DexQuote{
+		Mint:         mint,
+		PairAddress:  pair.PairAddress,
+		DexID:        pair.DexID,
+		PriceUSD:     priceUSD,
+		PriceNative:  priceNative,
+		LiquidityUSD: pair.Liquidity.USD,
+		VolumeM5:     pair.Volume.M5,
+		FetchedAt:    time.Now(),
+	}
+	d.mu.Lock()
+	d.cache[mint] = quote
+	d.mu.Unlock()
+	return quote, nil
+}
+
+func (d *Dexscreener) fetchPairs(ctx context.Context, mint string) ([]models.Pair, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.baseURL+"/"+mint, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("dexscreener returned status %d: %s", resp.StatusCode, body)
+	}
+	var data models.LastPriceDexResponse
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, err
+	}
+	return data.Pairs, nil
+}
+
+// mostLiquidSolanaPair picks the Solana pair with the highest USD liquidity in
+// which mint is the base token, so PriceUSD is the price of mint itself.
+func mostLiquidSolanaPair(pairs []models.Pair, mint string) *models.Pair {
+	var best *models.Pair
+	for i := range pairs {
+		pair := &pairs[i]
+		if pair.ChainID != "solana" || pair.BaseToken.Address != mint {
+			continue
+		}
+		if best == nil || pair.Liquidity.USD > best.Liquidity.USD {
+			best = pair
+		}
+	}
+	return best
+}
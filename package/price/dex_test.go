@@ -0,0 +1,117 @@
+package price
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testMint = "7GCihgDB8fe6KNjn2MYtkzZcRjQy3t9GHdC8uHYmW2hr"
+
+const pairsResponse = `{
+	"schemaVersion": "1.0.0",
+	"pairs": [
+		{
+			"chainId": "solana", "dexId": "raydium", "pairAddress": "smallPool",
+			"baseToken": {"address": "` + testMint + `", "symbol": "TST"},
+			"quoteToken": {"address": "So11111111111111111111111111111111111111112", "symbol": "SOL"},
+			"priceNative": "0.0000010", "priceUsd": "0.0002",
+			"volume": {"m5": 10}, "liquidity": {"usd": 1000}
+		},
+		{
+			"chainId": "solana", "dexId": "orca", "pairAddress": "deepPool",
+			"baseToken": {"address": "` + testMint + `", "symbol": "TST"},
+			"quoteToken": {"address": "So11111111111111111111111111111111111111112", "symbol": "SOL"},
+			"priceNative": "0.0000011", "priceUsd": "0.00022",
+			"volume": {"m5": 2500.5}, "liquidity": {"usd": 50000}
+		},
+		{
+			"chainId": "ethereum", "dexId": "uniswap", "pairAddress": "otherChain",
+			"baseToken": {"address": "` + testMint + `", "symbol": "TST"},
+			"priceNative": "1", "priceUsd": "9",
+			"liquidity": {"usd": 900000}
+		},
+		{
+			"chainId": "solana", "dexId": "raydium", "pairAddress": "quoteSide",
+			"baseToken": {"address": "So11111111111111111111111111111111111111112", "symbol": "SOL"},
+			"quoteToken": {"address": "` + testMint + `", "symbol": "TST"},
+			"priceNative": "900000", "priceUsd": "180",
+			"liquidity": {"usd": 800000}
+		}
+	]
+}`
+
+func newDexStandIn(t *testing.T, body string) (*httptest.Server, *int32) {
+	t.Helper()
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if r.URL.Path != "/latest/dex/tokens/"+testMint {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestDexscreenerPicksMostLiquidSolanaPair(t *testing.T) {
+	server, _ := newDexStandIn(t, pairsResponse)
+	source := NewDexscreener(server.URL+"/latest/dex/tokens/", http.DefaultClient, time.Minute)
+
+	quote, err := source.Quote(context.Background(), testMint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if quote.PairAddress != "deepPool" {
+		t.Fatalf("got pair %s, want deepPool", quote.PairAddress)
+	}
+	if quote.PriceUSD != 0.00022 || quote.PriceNative != 0.0000011 {
+		t.Fatalf("got prices %v USD / %v SOL", quote.PriceUSD, quote.PriceNative)
+	}
+	if quote.LiquidityUSD != 50000 || quote.VolumeM5 != 2500.5 {
+		t.Fatalf("got liquidity %v and 5m volume %v", quote.LiquidityUSD, quote.VolumeM5)
+	}
+}
+
+func TestDexscreenerCachesQuotes(t *testing.T) {
+	server, calls := newDexStandIn(t, pairsResponse)
+	source := NewDexscreener(server.URL+"/latest/dex/tokens", http.DefaultClient, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		price, err := source.PriceUSD(context.Background(), testMint)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if price != 0.00022 {
+			t.Fatalf("got price %v, want 0.00022", price)
+		}
+	}
+	if n := atomic.LoadInt32(calls); n != 1 {
+		t.Fatalf("got %d requests, want 1", n)
+	}
+
+	expiring := NewDexscreener(server.URL+"/latest/dex/tokens", http.DefaultClient, 0)
+	for i := 0; i < 2; i++ {
+		if _, err := expiring.PriceUSD(context.Background(), testMint); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := atomic.LoadInt32(calls); n != 3 {
+		t.Fatalf("got %d requests after cache expiry, want 3", n)
+	}
+}
+
+func TestDexscreenerNoSolanaPair(t *testing.T) {
+	server, _ := newDexStandIn(t, `{"schemaVersion": "1.0.0", "pairs": null}`)
+	source := NewDexscreener(server.URL+"/latest/dex/tokens", http.DefaultClient, time.Minute)
+
+	if _, err := source.Quote(context.Background(), testMint); err == nil {
+		t.Fatal("expected an error when no Solana pair exists")
+	}
+}
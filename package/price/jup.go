@@ -0,0 +1,130 @@
+package price
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// jupiterBatchSize is the maximum number of ids the Jupiter price API accepts
+// in a single request.
+const jupiterBatchSize = 100
+
+// Jupiter prices tokens with the Jupiter price API (JUP_HTTPS_PRICE_URI).
+// Many mints can be priced in a single request.
+type Jupiter struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewJupiter creates a Jupiter source for the price endpoint at baseURL.
+func NewJupiter(baseURL string, client *http.Client) *Jupiter {
+	return &Jupiter{
+		baseURL: baseURL,
+		client:  client,
+	}
+}
+
+// Name identifies the source as "jup".
+func (j *Jupiter) Name() string { return "jup" }
+
+// Price returns the USD price of mint.
+func (j *Jupiter) Price(ctx context.Context, mint string) (Quote, error) {
+	quotes, err := j.Prices(ctx, []string{mint})
+	if err != nil {
+		return Quote{}, err
+	}
+	quote, ok := quotes[mint]
+	if !ok {
+		return Quote{}, fmt.Errorf("price not found for %s", mint)
+	}
+	return quote, nil
+}
+
+// PriceUSD returns the USD price of mint.
+func (j *Jupiter) PriceUSD(ctx context.Context, mint string) (float64, error) {
+	quote, err := j.Price(ctx, mint)
+	if err != nil {
+		return 0, err
+	}
+	return quote.PriceUSD, nil
+}
+
+// Prices returns the USD prices of mints, batching them into as few requests
+// as possible. Mints Jupiter has no price for are left out of the result.
+func (j *Jupiter) Prices(ctx context.Context, mints []string) (map[string]Quote, error) {
+	quotes := make(map[string]Quote, len(mints))
+	for start := 0; start < len(mints); start += jupiterBatchSize {
+		end := start + jupiterBatchSize
+		if end > len(mints) {
+			end = len(mints)
+		}
+		if err := j.fetch(ctx, mints[start:end], quotes); err != nil {
+			return nil, err
+		}
+	}
+	return quotes, nil
+}
+
+func (j *Jupiter) fetch(ctx context.Context, mints []string, quotes map[string]Quote) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, j.baseURL, nil)
+	if err != nil {
+		return err
+	}
+	q := req.URL.Query()
+	q.Set("ids", strings.Join(mints, ","))
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := j.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("jupiter price API returned status %d: %s", resp.StatusCode, body)
+	}
+	var data struct {
+		Data map[string]*struct {
+			Price flexFloat `json:"price"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return err
+	}
+	now := time.Now()
+	for mint, entry := range data.Data {
+		// Unknown mints come back as null entries.
+		if entry != nil && entry.Price > 0 {
+			quotes[mint] = Quote{Mint: mint, PriceUSD: float64(entry.Price), Source: j.Name(), At: now}
+		}
+	}
+	return nil
+}
+
+// flexFloat decodes prices sent either as JSON numbers or as strings, which
+// differ between versions of the Jupiter price API.
+type flexFloat float64
+
+func (f *flexFloat) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" {
+		*f = 0
+		return nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+	*f = flexFloat(v)
+	return nil
+}
@@ -0,0 +1,75 @@
+package price
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newJupiterStandIn serves every requested id, as a string price on even
+// positions and a number on odd ones. Ids named "unknown" come back as null.
+func newJupiterStandIn(t *testing.T) (*Jupiter, *int) {
+	t.Helper()
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		var entries []string
+		for i, id := range strings.Split(r.URL.Query().Get("ids"), ",") {
+			switch {
+			case id == "unknown":
+				entries = append(entries, fmt.Sprintf(`%q: null`, id))
+			case i%2 == 0:
+				// v2 of the price API sends prices as strings.
+				entries = append(entries, fmt.Sprintf(`%q: {"id": %q, "price": "1.5"}`, id, id))
+			default:
+				entries = append(entries, fmt.Sprintf(`%q: {"id": %q, "price": 2.5}`, id, id))
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"data": {%s}}`, strings.Join(entries, ","))
+	}))
+	t.Cleanup(server.Close)
+	return NewJupiter(server.URL, http.DefaultClient), &calls
+}
+
+func TestJupiterPricesBatchesMints(t *testing.T) {
+	jup, calls := newJupiterStandIn(t)
+	mints := []string{"unknown"}
+	for i := 0; i < jupiterBatchSize+4; i++ {
+		mints = append(mints, fmt.Sprintf("mint%d", i))
+	}
+
+	prices, err := jup.Prices(context.Background(), mints)
+	if err != nil {
+		t.Fatalf("Prices: %v", err)
+	}
+	if *calls != 2 {
+		t.Fatalf("requests = %d, want 2", *calls)
+	}
+	if len(prices) != len(mints)-1 {
+		t.Fatalf("got %d prices, want %d", len(prices), len(mints)-1)
+	}
+	if _, ok := prices["unknown"]; ok {
+		t.Errorf("unknown mint should be left out")
+	}
+	if prices["mint1"].PriceUSD != 1.5 || prices["mint2"].PriceUSD != 2.5 {
+		t.Errorf("prices = %v, %v; want 1.5, 2.5", prices["mint1"].PriceUSD, prices["mint2"].PriceUSD)
+	}
+	if prices["mint1"].Source != "jup" {
+		t.Errorf("source = %q, want jup", prices["mint1"].Source)
+	}
+}
+
+func TestJupiterPriceUSDMissing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": {}}`))
+	}))
+	defer server.Close()
+
+	if _, err := NewJupiter(server.URL, http.DefaultClient).PriceUSD(context.Background(), testMint); err == nil {
+		t.Fatal("expected an error for a mint without a price")
+	}
+}
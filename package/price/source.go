@@ -0,0 +1,161 @@
+package price
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Quote is a USD price together with the source that reported it and when it
+// was observed.
+type Quote struct {
+	Mint     string
+	PriceUSD float64
+	Source   string
+	At       time.Time
+}
+
+// Source is a price feed for single tokens. Name is the identifier used in
+// SellConfig.PriceSource and SellConfig.PriceFallbacks.
+type Source interface {
+	Name() string
+	Price(ctx context.Context, mint string) (Quote, error)
+}
+
+// BatchSource is a Source that can price many tokens in a single request.
+// Mints it has no price for are left out of the result.
+type BatchSource interface {
+	Source
+	Prices(ctx context.Context, mints []string) (map[string]Quote, error)
+}
+
+// Composite asks its sources in order and only accepts a quote that is fresh
+// and, when cross-checking is enabled, agrees with the next source that
+// answers. This keeps a single bad feed from triggering a sell, at the cost of
+// leaving tokens only one source can price without a price.
+type Composite struct {
+	sources      []Source
+	maxDeviation float64       // percent, 0 disables the cross-check
+	maxAge       time.Duration // 0 disables the staleness check
+}
+
+// NewComposite creates a Composite trying sources in the given order.
+func NewComposite(sources []Source, maxDeviationPercent float64, maxAge time.Duration) *Composite {
+	return &Composite{
+		sources:      sources,
+		maxDeviation: maxDeviationPercent,
+		maxAge:       maxAge,
+	}
+}
+
+// Name lists the sources of the chain, e.g. "dex>jup".
+func (c *Composite) Name() string {
+	names := make([]string, len(c.sources))
+	for i, source := range c.sources {
+		names[i] = source.Name()
+	}
+	return strings.Join(names, ">")
+}
+
+// Price returns the first accepted quote for mint.
+func (c *Composite) Price(ctx context.Context, mint string) (Quote, error) {
+	quotes, err := c.Prices(ctx, []string{mint})
+	if quote, ok := quotes[mint]; ok {
+		return quote, nil
+	}
+	if err == nil {
+		err = fmt.Errorf("price not found for %s", mint)
+	}
+	return Quote{}, err
+}
+
+// Prices returns an accepted quote for every mint it could price. Batch
+// sources are asked for all pending mints at once. The error lists why the
+// remaining mints were rejected and is non-nil whenever a mint is missing.
+func (c *Composite) Prices(ctx context.Context, mints []string) (map[string]Quote, error) {
+	needed := 1
+	if c.maxDeviation > 0 && len(c.sources) > 1 {
+		needed = 2
+	}
+
+	fresh := make(map[string][]Quote, len(mints))
+	var errs []error
+	for _, source := range c.sources {
+		var pending []string
+		for _, mint := range mints {
+			if len(fresh[mint]) < needed {
+				pending = append(pending, mint)
+			}
+		}
+		if len(pending) == 0 {
+			break
+		}
+
+		quotes, err := fetchQuotes(ctx, source, pending)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %v", source.Name(), err))
+		}
+		for _, mint := range pending {
+			quote, ok := quotes[mint]
+			if !ok {
+				continue
+			}
+			if age := time.Since(quote.At); c.maxAge > 0 && age > c.maxAge {
+				errs = append(errs, fmt.Errorf("%s: quote for %s is %s old", source.Name(), mint, age.Round(time.Millisecond)))
+				continue
+			}
+			fresh[mint] = append(fresh[mint], quote)
+		}
+	}
+
+	accepted := make(map[string]Quote, len(mints))
+	for _, mint := range mints {
+		quotes := fresh[mint]
+		switch {
+		case len(quotes) == 0:
+			errs = append(errs, fmt.Errorf("no source could price %s", mint))
+		case len(quotes) < needed:
+			errs = append(errs, fmt.Errorf("%s quote for %s could not be confirmed by another source", quotes[0].Source, mint))
+		case needed == 2 && deviation(quotes[0].PriceUSD, quotes[1].PriceUSD) > c.maxDeviation:
+			errs = append(errs, fmt.Errorf("%s and %s disagree on %s: %g vs %g USD",
+				quotes[0].Source, quotes[1].Source, mint, quotes[0].PriceUSD, quotes[1].PriceUSD))
+		default:
+			accepted[mint] = quotes[0]
+		}
+	}
+	if len(accepted) == len(mints) {
+		return accepted, nil
+	}
+	return accepted, errors.Join(errs...)
+}
+
+// fetchQuotes prices mints with source, in one request if it supports
+// batching. Per mint failures are joined into the error.
+func fetchQuotes(ctx context.Context, source Source, mints []string) (map[string]Quote, error) {
+	if batch, ok := source.(BatchSource); ok {
+		return batch.Prices(ctx, mints)
+	}
+	quotes := make(map[string]Quote, len(mints))
+	var errs []error
+	for _, mint := range mints {
+		quote, err := source.Price(ctx, mint)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		quotes[mint] = quote
+	}
+	return quotes, errors.Join(errs...)
+}
+
+// deviation returns how far apart a and b are, in percent of the lower one.
+func deviation(a, b float64) float64 {
+	low := math.Min(a, b)
+	if low <= 0 {
+		return math.Inf(1)
+	}
+	return math.Abs(a-b) / low * 100
+}
@@ -0,0 +1,101 @@
+package price
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type fakeSource struct {
+	name   string
+	prices map[string]float64
+	age    time.Duration
+	calls  int
+}
+
+func (f *fakeSource) Name() string { return f.name }
+
+func (f *fakeSource) Price(ctx context.Context, mint string) (Quote, error) {
+	f.calls++
+	price, ok := f.prices[mint]
+	if !ok {
+		return Quote{}, errors.New("no price")
+	}
+	return Quote{Mint: mint, PriceUSD: price, Source: f.name, At: time.Now().Add(-f.age)}, nil
+}
+
+func TestCompositeFallsBackInOrder(t *testing.T) {
+	primary := &fakeSource{name: "primary", prices: map[string]float64{}}
+	fallback := &fakeSource{name: "fallback", prices: map[string]float64{testMint: 2}}
+	unused := &fakeSource{name: "unused", prices: map[string]float64{testMint: 3}}
+
+	quote, err := NewComposite([]Source{primary, fallback, unused}, 0, 0).Price(context.Background(), testMint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if quote.Source != "fallback" || quote.PriceUSD != 2 {
+		t.Fatalf("got %v from %s, want 2 from fallback", quote.PriceUSD, quote.Source)
+	}
+	if unused.calls != 0 {
+		t.Fatalf("unused source was asked %d times", unused.calls)
+	}
+}
+
+func TestCompositeRejectsStaleQuotes(t *testing.T) {
+	stale := &fakeSource{name: "stale", prices: map[string]float64{testMint: 1}, age: time.Minute}
+	fresh := &fakeSource{name: "fresh", prices: map[string]float64{testMint: 1.01}}
+
+	quote, err := NewComposite([]Source{stale, fresh}, 0, time.Second).Price(context.Background(), testMint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if quote.Source != "fresh" {
+		t.Fatalf("got quote from %s, want fresh", quote.Source)
+	}
+
+	if _, err := NewComposite([]Source{stale}, 0, time.Second).Price(context.Background(), testMint); err == nil {
+		t.Fatal("expected an error when every quote is stale")
+	}
+}
+
+func TestCompositeCrossChecksSources(t *testing.T) {
+	primary := &fakeSource{name: "primary", prices: map[string]float64{testMint: 1}}
+	agreeing := &fakeSource{name: "agreeing", prices: map[string]float64{testMint: 1.05}}
+	broken := &fakeSource{name: "broken", prices: map[string]float64{testMint: 0.1}}
+	down := &fakeSource{name: "down", prices: map[string]float64{}}
+
+	quote, err := NewComposite([]Source{primary, agreeing}, 10, 0).Price(context.Background(), testMint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if quote.Source != "primary" || quote.PriceUSD != 1 {
+		t.Fatalf("got %v from %s, want 1 from primary", quote.PriceUSD, quote.Source)
+	}
+
+	if _, err := NewComposite([]Source{primary, broken}, 10, 0).Price(context.Background(), testMint); err == nil {
+		t.Fatal("expected an error when sources disagree")
+	}
+	if _, err := NewComposite([]Source{broken, primary}, 10, 0).Price(context.Background(), testMint); err == nil {
+		t.Fatal("expected an error when the primary source is the bad one")
+	}
+	if _, err := NewComposite([]Source{primary, down}, 10, 0).Price(context.Background(), testMint); err == nil {
+		t.Fatal("expected an error when no source confirms the quote")
+	}
+}
+
+func TestCompositeUsesBatchSources(t *testing.T) {
+	jup, calls := newJupiterStandIn(t)
+	mints := []string{"mint0", "mint1", "mint2"}
+
+	quotes, err := NewComposite([]Source{jup}, 0, 0).Prices(context.Background(), mints)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(quotes) != len(mints) {
+		t.Fatalf("got %d quotes, want %d", len(quotes), len(mints))
+	}
+	if *calls != 1 {
+		t.Fatalf("got %d requests, want 1", *calls)
+	}
+}
@@ -0,0 +1,64 @@
+package pumpfun
+
+import (
+	"strings"
+
+	"github.com/low4ey/sniper/package/models"
+)
+
+const (
+	// ProgramID is the pump.fun bonding curve program.
+	ProgramID = "6EF8rrecthR5Dkzon8Nwu78hRvfCKubJ14M5uBEwF6P"
+	// MigrationAccount creates the Raydium pool of a token whose bonding
+	// curve completed.
+	MigrationAccount = "39azUYFWPz3VHgKCf3VChUwbpURdCHRxjWVowf5jUJjg"
+	// MintSuffix ends the vanity mint addresses pump.fun gives its tokens.
+	MintSuffix = "pump"
+)
+
+// Policy values of RugCheckConfig.PumpFunPolicy.
+const (
+	PolicySkip   = "skip"   // Never buy pump.fun tokens
+	PolicyAllow  = "allow"  // Buy pump.fun tokens like any other
+	PolicyPrefer = "prefer" // Also listen for migrations and queue them first
+)
+
+// IsPumpFun reports whether tokenMint was launched on pump.fun, judged by its
+// mint suffix and by whether tx, the transaction creating its pool, involves
+// the pump.fun program or migration account. tx may be nil.
+func IsPumpFun(tokenMint string, tx *models.TransactionDetailResponse) bool {
+	if strings.HasSuffix(tokenMint, MintSuffix) {
+		return true
+	}
+	if tx == nil {
+		return false
+	}
+	if tx.FeePayer == MigrationAccount {
+		return true
+	}
+	for _, inst := range tx.Instructions {
+		if inst.ProgramId == ProgramID || contains(inst.Accounts, MigrationAccount) {
+			return true
+		}
+		for _, inner := range inst.InnerInstructions {
+			if inner.ProgramId == ProgramID {
+				return true
+			}
+		}
+	}
+	for _, account := range tx.AccountData {
+		if account.Account == ProgramID || account.Account == MigrationAccount {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(slice []string, item string) bool {
+	for _, s := range slice {
+		if s == item {
+			return true
+		}
+	}
+	return false
+}
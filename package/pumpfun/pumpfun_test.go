@@ -0,0 +1,40 @@
+package pumpfun
+
+import (
+	"testing"
+
+	"github.com/low4ey/sniper/package/models"
+)
+
+const raydiumProgramID = "675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8"
+
+func TestIsPumpFun(t *testing.T) {
+	const mint = "7GCihgDB8fe6KNjn2MYtkzZcRjQy3t9GHdC8uHYmW2hr"
+	tests := []struct {
+		name string
+		mint string
+		tx   *models.TransactionDetailResponse
+		want bool
+	}{
+		{"vanity mint", "CzLSujWBLFsSjncfkh59rUFqvafWcY5tzedWJSuypump", nil, true},
+		{"plain mint without transaction", mint, nil, false},
+		{"migration fee payer", mint, &models.TransactionDetailResponse{FeePayer: MigrationAccount}, true},
+		{"migration account in instruction", mint, &models.TransactionDetailResponse{
+			Instructions: []models.Instructions{{ProgramId: raydiumProgramID, Accounts: []string{mint, MigrationAccount}}},
+		}, true},
+		{"bonding curve program", mint, &models.TransactionDetailResponse{
+			Instructions: []models.Instructions{{ProgramId: ProgramID}},
+		}, true},
+		{"regular pool creation", mint, &models.TransactionDetailResponse{
+			FeePayer:     "9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM",
+			Instructions: []models.Instructions{{ProgramId: raydiumProgramID, Accounts: []string{mint}}},
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsPumpFun(tt.mint, tt.tx); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
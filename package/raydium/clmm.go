@@ -0,0 +1,44 @@
+package raydium
+
+import "encoding/binary"
+
+// Positions of the accounts of a CLMM create_pool instruction.
+const (
+	clmmAmmConfig   = 1
+	clmmPoolState   = 2
+	clmmToken0Mint  = 3
+	clmmToken1Mint  = 4
+	clmmToken0Vault = 5
+	clmmToken1Vault = 6
+	clmmObservation = 7
+
+	clmmCreatePoolAccounts = 8          // accounts up to and including the observation state
+	clmmCreatePoolDataLen  = 8 + 16 + 8 // discriminator, sqrt_price_x64, open_time
+)
+
+// poolFromClmmCreatePool decodes the pool created by a CLMM create_pool
+// instruction. Liquidity is added by separate positions, so the pool starts
+// without reserves.
+func poolFromClmmCreatePool(accounts []string, data string) (*Pool, error) {
+	raw, err := anchorData("create_pool", data, clmmCreatePoolDiscriminator, clmmCreatePoolDataLen)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := parseAccounts("create_pool", accounts, clmmCreatePoolAccounts)
+	if err != nil {
+		return nil, err
+	}
+	return &Pool{
+		Type: Clmm,
+		PoolKeys: PoolKeys{
+			ID:          keys[clmmPoolState],
+			BaseMint:    keys[clmmToken0Mint],
+			QuoteMint:   keys[clmmToken1Mint],
+			BaseVault:   keys[clmmToken0Vault],
+			QuoteVault:  keys[clmmToken1Vault],
+			AmmConfig:   keys[clmmAmmConfig],
+			Observation: keys[clmmObservation],
+		},
+		OpenTime: unixTime(binary.LittleEndian.Uint64(raw[24:32])),
+	}, nil
+}
@@ -0,0 +1,84 @@
+package raydium
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/mr-tron/base58"
+)
+
+// Anchor discriminators of the pool creation instructions, the first 8 bytes
+// of sha256("global:<name>").
+var (
+	cpmmInitializeDiscriminator = []byte{175, 175, 109, 31, 13, 152, 155, 237}
+	clmmCreatePoolDiscriminator = []byte{233, 146, 209, 142, 207, 104, 64, 188}
+)
+
+// Positions of the accounts of a CPMM initialize instruction.
+const (
+	cpmmAmmConfig   = 1
+	cpmmAuthority   = 2
+	cpmmPoolState   = 3
+	cpmmToken0Mint  = 4
+	cpmmToken1Mint  = 5
+	cpmmLPMint      = 6
+	cpmmToken0Vault = 10
+	cpmmToken1Vault = 11
+	cpmmObservation = 13
+
+	cpmmInitializeAccounts = 14      // accounts up to and including the observation state
+	cpmmInitializeDataLen  = 8 + 3*8 // discriminator, init_amount_0, init_amount_1, open_time
+)
+
+// poolFromCpmmInitialize decodes the pool created by a CPMM initialize
+// instruction.
+func poolFromCpmmInitialize(accounts []string, data string) (*Pool, error) {
+	raw, err := anchorData("initialize", data, cpmmInitializeDiscriminator, cpmmInitializeDataLen)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := parseAccounts("initialize", accounts, cpmmInitializeAccounts)
+	if err != nil {
+		return nil, err
+	}
+	return &Pool{
+		Type: Cpmm,
+		PoolKeys: PoolKeys{
+			ID:          keys[cpmmPoolState],
+			Authority:   keys[cpmmAuthority],
+			LPMint:      keys[cpmmLPMint],
+			BaseMint:    keys[cpmmToken0Mint],
+			QuoteMint:   keys[cpmmToken1Mint],
+			BaseVault:   keys[cpmmToken0Vault],
+			QuoteVault:  keys[cpmmToken1Vault],
+			AmmConfig:   keys[cpmmAmmConfig],
+			Observation: keys[cpmmObservation],
+		},
+		InitBaseReserve:  binary.LittleEndian.Uint64(raw[8:16]),
+		InitQuoteReserve: binary.LittleEndian.Uint64(raw[16:24]),
+		OpenTime:         unixTime(binary.LittleEndian.Uint64(raw[24:32])),
+	}, nil
+}
+
+// anchorData decodes the base58 data of the named Anchor instruction, checking
+// its discriminator and minimum length.
+func anchorData(instruction, data string, discriminator []byte, minLen int) ([]byte, error) {
+	raw, err := base58.Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid instruction data: %v", err)
+	}
+	if len(raw) < minLen || !bytes.Equal(raw[:8], discriminator) {
+		return nil, fmt.Errorf("instruction data is not %s", instruction)
+	}
+	return raw, nil
+}
+
+// unixTime converts an on-chain open time, 0 meaning open on creation.
+func unixTime(seconds uint64) time.Time {
+	if seconds == 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(seconds), 0)
+}
@@ -0,0 +1,80 @@
+package raydium
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/mr-tron/base58"
+)
+
+const (
+	initialize2Discriminator = 1
+	initialize2DataLen       = 26 // discriminator, nonce, open_time, init_pc_amount, init_coin_amount
+)
+
+// Initialize2 holds the arguments of the initialize2 instruction that
+// creates an AMM v4 pool.
+type Initialize2 struct {
+	Nonce          uint8
+	OpenTime       uint64 // Unix time (seconds) from which the pool accepts swaps, 0 if immediately
+	InitPcAmount   uint64 // Quote deposited on creation
+	InitCoinAmount uint64 // Base deposited on creation
+}
+
+// DecodeInitialize2 decodes the base58 encoded data of an initialize2
+// instruction, as found in models.Instructions.Data.
+func DecodeInitialize2(data string) (*Initialize2, error) {
+	raw, err := base58.Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid instruction data: %v", err)
+	}
+	if len(raw) < initialize2DataLen || raw[0] != initialize2Discriminator {
+		return nil, fmt.Errorf("instruction data is not initialize2")
+	}
+	return &Initialize2{
+		Nonce:          raw[1],
+		OpenTime:       binary.LittleEndian.Uint64(raw[2:10]),
+		InitPcAmount:   binary.LittleEndian.Uint64(raw[10:18]),
+		InitCoinAmount: binary.LittleEndian.Uint64(raw[18:26]),
+	}, nil
+}
+
+// Pool describes a newly created pool: its type, keys and the state it was
+// created with.
+type Pool struct {
+	Type PoolType
+	PoolKeys
+	InitBaseReserve  uint64
+	InitQuoteReserve uint64
+	OpenTime         time.Time // Zero if the pool opened on creation
+}
+
+// PoolFromInitialize2 builds the pool created by an initialize2 instruction
+// from its account list and base58 encoded data.
+func PoolFromInitialize2(accounts []string, data string) (*Pool, error) {
+	args, err := DecodeInitialize2(data)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := PoolKeysFromInitialize2(accounts)
+	if err != nil {
+		return nil, err
+	}
+	return &Pool{
+		Type:             AmmV4,
+		PoolKeys:         *keys,
+		InitBaseReserve:  args.InitCoinAmount,
+		InitQuoteReserve: args.InitPcAmount,
+		OpenTime:         unixTime(args.OpenTime),
+	}, nil
+}
+
+// OpensIn returns how long after now the pool starts accepting swaps, or 0
+// if it already does.
+func (p *Pool) OpensIn(now time.Time) time.Duration {
+	if p.OpenTime.IsZero() || !p.OpenTime.After(now) {
+		return 0
+	}
+	return p.OpenTime.Sub(now)
+}
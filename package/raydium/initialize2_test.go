@@ -0,0 +1,139 @@
+package raydium
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/mr-tron/base58"
+)
+
+// encodeInitialize2 builds base58 initialize2 instruction data.
+func encodeInitialize2(nonce uint8, openTime, initPcAmount, initCoinAmount uint64) string {
+	raw := make([]byte, initialize2DataLen)
+	raw[0] = initialize2Discriminator
+	raw[1] = nonce
+	binary.LittleEndian.PutUint64(raw[2:], openTime)
+	binary.LittleEndian.PutUint64(raw[10:], initPcAmount)
+	binary.LittleEndian.PutUint64(raw[18:], initCoinAmount)
+	return base58.Encode(raw)
+}
+
+func TestDecodeInitialize2(t *testing.T) {
+	got, err := DecodeInitialize2(encodeInitialize2(254, 1_700_000_000, 79_000_000_000, 206_900_000_000_000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Initialize2{Nonce: 254, OpenTime: 1_700_000_000, InitPcAmount: 79_000_000_000, InitCoinAmount: 206_900_000_000_000}
+	if *got != want {
+		t.Fatalf("got %+v, want %+v", *got, want)
+	}
+
+	for name, data := range map[string]string{
+		"not base58":        "0OIl",
+		"too short":         base58.Encode([]byte{initialize2Discriminator, 1, 2}),
+		"other instruction": base58.Encode(make([]byte, initialize2DataLen)),
+	} {
+		if _, err := DecodeInitialize2(data); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestPoolFromInitialize2(t *testing.T) {
+	keys := newKeys(21)
+	accounts := make([]string, len(keys))
+	for i, key := range keys {
+		accounts[i] = key.String()
+	}
+
+	pool, err := PoolFromInitialize2(accounts, encodeInitialize2(1, 1_700_000_000, 500, 1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pool.ID != keys[4] || pool.InitBaseReserve != 1000 || pool.InitQuoteReserve != 500 || !pool.OpenTime.Equal(time.Unix(1_700_000_000, 0)) {
+		t.Fatalf("unexpected pool %+v", pool)
+	}
+
+	pool, err = PoolFromInitialize2(accounts, encodeInitialize2(1, 0, 500, 1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pool.OpenTime.IsZero() {
+		t.Fatalf("open time %v, want zero for a pool open on creation", pool.OpenTime)
+	}
+}
+
+func TestPoolOpensIn(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	tests := []struct {
+		name     string
+		openTime time.Time
+		want     time.Duration
+	}{
+		{"open on creation", time.Time{}, 0},
+		{"already open", now.Add(-time.Minute), 0},
+		{"opening now", now, 0},
+		{"opening later", now.Add(90 * time.Second), 90 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := &Pool{OpenTime: tt.openTime}
+			if got := pool.OpensIn(now); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// mainnetInitialize2Accounts is the initialize2 account list of the mainnet
+// SOL/USDC AMM v4 pool, up to and including the market. The creator's
+// wallet and token accounts that follow are not read.
+var mainnetInitialize2Accounts = []string{
+	"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA",  // token program
+	"ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL", // associated token program
+	"11111111111111111111111111111111",             // system program
+	"SysvarRent111111111111111111111111111111111",  // rent sysvar
+	"58oQChx4yWmvKdwLLZzBi4ChoCc2fqCUWBkwMihLYQo2", // amm
+	"5Q544fKrFoe6tsEbD7S8EmxGTJYAKtTVhAW5Q5pge4j1", // amm authority
+	"HmiHHzq4Fym9e1D4qzLS6LDDM3tNsCTBPDWHTLZ763jY", // amm open orders
+	"8HoQnePLqPj4M7PUDzfw8e3Ymdwgc7NLGnaTUapubyvu", // lp mint
+	"So11111111111111111111111111111111111111112",  // coin mint
+	"EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v", // pc mint
+	"DQyrAcCrDXQ7NeoqGgDCZwBvWDcYmFCjSb9JtteuvPpz", // coin vault
+	"HLmqeL62xR1QoZ1HKKbXRrdN1p3phKpxRMb2VVopvBBz", // pc vault
+	"CZza3Ej4Mc58MnxWA385itCC9jCo3L1D7zc3LKy1bZMR", // amm target orders
+	"9DCxsMizn3H1hprZ7xWe6LDzeUeZBksYFpBWBtSf1PQX", // amm config
+	"7YttLkHDoNj9wyDur5pM1ejNaAvT9X4eqaYcHQqtj2G5", // create fee destination
+	"srmqPvymJeFKQ4zGQed1GFppgkRHL9kaELCbyksJtPX",  // market program
+	"8BnEgHoWFysVcuFFX7QztDmzuH8r5ZFvyP3sYwn1XTh6", // market
+}
+
+func TestPoolFromInitialize2Mainnet(t *testing.T) {
+	accounts := append(append([]string{}, mainnetInitialize2Accounts...), accountStrings(4)...)
+	pool, err := PoolFromInitialize2(accounts, encodeInitialize2(254, 0, 79_000_000_000, 206_900_000_000))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := PoolKeys{
+		ID:              solana.MustPublicKeyFromBase58("58oQChx4yWmvKdwLLZzBi4ChoCc2fqCUWBkwMihLYQo2"),
+		Authority:       solana.MustPublicKeyFromBase58("5Q544fKrFoe6tsEbD7S8EmxGTJYAKtTVhAW5Q5pge4j1"),
+		OpenOrders:      solana.MustPublicKeyFromBase58("HmiHHzq4Fym9e1D4qzLS6LDDM3tNsCTBPDWHTLZ763jY"),
+		TargetOrders:    solana.MustPublicKeyFromBase58("CZza3Ej4Mc58MnxWA385itCC9jCo3L1D7zc3LKy1bZMR"),
+		LPMint:          solana.MustPublicKeyFromBase58("8HoQnePLqPj4M7PUDzfw8e3Ymdwgc7NLGnaTUapubyvu"),
+		BaseMint:        solana.WrappedSol,
+		QuoteMint:       solana.MustPublicKeyFromBase58("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"),
+		BaseVault:       solana.MustPublicKeyFromBase58("DQyrAcCrDXQ7NeoqGgDCZwBvWDcYmFCjSb9JtteuvPpz"),
+		QuoteVault:      solana.MustPublicKeyFromBase58("HLmqeL62xR1QoZ1HKKbXRrdN1p3phKpxRMb2VVopvBBz"),
+		MarketProgramID: solana.MustPublicKeyFromBase58("srmqPvymJeFKQ4zGQed1GFppgkRHL9kaELCbyksJtPX"),
+		MarketID:        solana.MustPublicKeyFromBase58("8BnEgHoWFysVcuFFX7QztDmzuH8r5ZFvyP3sYwn1XTh6"),
+	}
+	if pool.PoolKeys != want {
+		t.Fatalf("got %+v, want %+v", pool.PoolKeys, want)
+	}
+	if pool.Type != AmmV4 || pool.InitBaseReserve != 206_900_000_000 || pool.InitQuoteReserve != 79_000_000_000 || !pool.OpenTime.IsZero() {
+		t.Fatalf("unexpected pool state %+v", pool)
+	}
+}
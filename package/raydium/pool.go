@@ -0,0 +1,129 @@
+package raydium
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/serum"
+)
+
+// AmmV4ProgramID is the Raydium liquidity pool v4 program.
+var AmmV4ProgramID = solana.MustPublicKeyFromBase58("675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8")
+
+// Positions of the accounts of an initialize2 instruction.
+const (
+	initAmm           = 4
+	initAuthority     = 5
+	initOpenOrders    = 6
+	initLPMint        = 7
+	initBaseMint      = 8
+	initQuoteMint     = 9
+	initBaseVault     = 10
+	initQuoteVault    = 11
+	initTargetOrders  = 12
+	initMarketProgram = 15
+	initMarket        = 16
+
+	initialize2Accounts = 17 // accounts up to and including the market
+)
+
+// PoolKeys are the accounts of a pool needed to swap against it. Base is the
+// pool's coin (token 0) side and Quote its pc (token 1) side. Fields a pool
+// type has no account for are left zero.
+type PoolKeys struct {
+	ID              solana.PublicKey
+	Authority       solana.PublicKey
+	OpenOrders      solana.PublicKey // AMM v4
+	TargetOrders    solana.PublicKey // AMM v4
+	LPMint          solana.PublicKey
+	BaseMint        solana.PublicKey
+	QuoteMint       solana.PublicKey
+	BaseVault       solana.PublicKey
+	QuoteVault      solana.PublicKey
+	MarketProgramID solana.PublicKey // AMM v4
+	MarketID        solana.PublicKey // AMM v4
+	AmmConfig       solana.PublicKey // CPMM and CLMM
+	Observation     solana.PublicKey // CPMM and CLMM
+}
+
+// PoolKeysFromInitialize2 reads the pool keys from the account list of the
+// initialize2 instruction that created the pool.
+func PoolKeysFromInitialize2(accounts []string) (*PoolKeys, error) {
+	keys, err := parseAccounts("initialize2", accounts, initialize2Accounts)
+	if err != nil {
+		return nil, err
+	}
+	return &PoolKeys{
+		ID:              keys[initAmm],
+		Authority:       keys[initAuthority],
+		OpenOrders:      keys[initOpenOrders],
+		TargetOrders:    keys[initTargetOrders],
+		LPMint:          keys[initLPMint],
+		BaseMint:        keys[initBaseMint],
+		QuoteMint:       keys[initQuoteMint],
+		BaseVault:       keys[initBaseVault],
+		QuoteVault:      keys[initQuoteVault],
+		MarketProgramID: keys[initMarketProgram],
+		MarketID:        keys[initMarket],
+	}, nil
+}
+
+// parseAccounts decodes the first n accounts of the named instruction.
+func parseAccounts(instruction string, accounts []string, n int) ([]solana.PublicKey, error) {
+	if len(accounts) < n {
+		return nil, fmt.Errorf("%s instruction has %d accounts, want at least %d", instruction, len(accounts), n)
+	}
+	keys := make([]solana.PublicKey, n)
+	for i := range keys {
+		key, err := solana.PublicKeyFromBase58(accounts[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid account %d %q: %v", i, accounts[i], err)
+		}
+		keys[i] = key
+	}
+	return keys, nil
+}
+
+// MarketKeys are the OpenBook market accounts a swap instruction must pass
+// along with the pool keys.
+type MarketKeys struct {
+	Bids        solana.PublicKey
+	Asks        solana.PublicKey
+	EventQueue  solana.PublicKey
+	BaseVault   solana.PublicKey
+	QuoteVault  solana.PublicKey
+	VaultSigner solana.PublicKey
+}
+
+// DecodeMarket reads the market keys of pool from the market account data.
+func DecodeMarket(pool *PoolKeys, data []byte) (*MarketKeys, error) {
+	var market serum.MarketV2
+	if err := market.Decode(data); err != nil {
+		return nil, fmt.Errorf("failed to decode market %s: %v", pool.MarketID, err)
+	}
+	nonce := make([]byte, 8)
+	binary.LittleEndian.PutUint64(nonce, uint64(market.VaultSignerNonce))
+	vaultSigner, err := solana.CreateProgramAddress([][]byte{pool.MarketID.Bytes(), nonce}, pool.MarketProgramID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive vault signer of market %s: %v", pool.MarketID, err)
+	}
+	return &MarketKeys{
+		Bids:        market.Bids,
+		Asks:        market.Asks,
+		EventQueue:  market.EventQueue,
+		BaseVault:   market.BaseVault,
+		QuoteVault:  market.QuoteVault,
+		VaultSigner: vaultSigner,
+	}, nil
+}
+
+// TokenAccountAmount reads the amount held by an SPL token account from its
+// data.
+func TokenAccountAmount(data []byte) (uint64, error) {
+	// mint (32) | owner (32) | amount (8) | ...
+	if len(data) < 72 {
+		return 0, fmt.Errorf("token account data is %d bytes, want at least 72", len(data))
+	}
+	return binary.LittleEndian.Uint64(data[64:72]), nil
+}
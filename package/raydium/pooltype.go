@@ -0,0 +1,53 @@
+package raydium
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PoolType identifies the Raydium program a pool was created on.
+type PoolType string
+
+const (
+	AmmV4 PoolType = "amm_v4" // Liquidity pool v4, created by initialize2
+	Cpmm  PoolType = "cpmm"   // Constant product market maker, created by initialize
+	Clmm  PoolType = "clmm"   // Concentrated liquidity market maker, created by create_pool
+)
+
+// ParseCreation decodes the pool created by an instruction of a poolType
+// program from the instruction's account list and base58 encoded data.
+func ParseCreation(poolType PoolType, accounts []string, data string) (*Pool, error) {
+	switch poolType {
+	case AmmV4:
+		return PoolFromInitialize2(accounts, data)
+	case Cpmm:
+		return poolFromCpmmInitialize(accounts, data)
+	case Clmm:
+		return poolFromClmmCreatePool(accounts, data)
+	default:
+		return nil, fmt.Errorf("unknown pool type %q", poolType)
+	}
+}
+
+// IsCreationLog reports whether logs, the log messages of a transaction
+// mentioning a poolType program, include the creation of a pool.
+func IsCreationLog(poolType PoolType, logs []string) bool {
+	for _, line := range logs {
+		switch poolType {
+		case AmmV4:
+			if strings.Contains(line, "initialize2") {
+				return true
+			}
+		case Cpmm:
+			// Exact match, token programs log InitializeAccount and the like.
+			if line == "Program log: Instruction: Initialize" {
+				return true
+			}
+		case Clmm:
+			if line == "Program log: Instruction: CreatePool" {
+				return true
+			}
+		}
+	}
+	return false
+}
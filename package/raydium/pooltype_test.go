@@ -0,0 +1,88 @@
+package raydium
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/mr-tron/base58"
+)
+
+func accountStrings(n int) []string {
+	keys := newKeys(n)
+	accounts := make([]string, n)
+	for i, key := range keys {
+		accounts[i] = key.String()
+	}
+	return accounts
+}
+
+func TestParseCreationCpmm(t *testing.T) {
+	accounts := accountStrings(20)
+	raw := append([]byte{}, cpmmInitializeDiscriminator...)
+	raw = binary.LittleEndian.AppendUint64(raw, 2_000_000_000)       // init_amount_0
+	raw = binary.LittleEndian.AppendUint64(raw, 500_000_000_000_000) // init_amount_1
+	raw = binary.LittleEndian.AppendUint64(raw, 1_700_000_000)       // open_time
+
+	pool, err := ParseCreation(Cpmm, accounts, base58.Encode(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pool.Type != Cpmm || pool.ID.String() != accounts[3] || pool.BaseMint.String() != accounts[4] ||
+		pool.QuoteMint.String() != accounts[5] || pool.BaseVault.String() != accounts[10] || pool.QuoteVault.String() != accounts[11] {
+		t.Fatalf("unexpected pool keys %+v", pool.PoolKeys)
+	}
+	if pool.InitBaseReserve != 2_000_000_000 || pool.InitQuoteReserve != 500_000_000_000_000 || !pool.OpenTime.Equal(time.Unix(1_700_000_000, 0)) {
+		t.Fatalf("unexpected pool state %+v", pool)
+	}
+
+	if _, err := ParseCreation(Cpmm, accounts, encodeInitialize2(1, 0, 1, 1)); err == nil {
+		t.Fatal("expected an error for initialize2 data")
+	}
+}
+
+func TestParseCreationClmm(t *testing.T) {
+	accounts := accountStrings(13)
+	raw := append([]byte{}, clmmCreatePoolDiscriminator...)
+	raw = append(raw, make([]byte, 16)...) // sqrt_price_x64
+	raw = binary.LittleEndian.AppendUint64(raw, 0)
+
+	pool, err := ParseCreation(Clmm, accounts, base58.Encode(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pool.Type != Clmm || pool.ID.String() != accounts[2] || pool.BaseMint.String() != accounts[3] ||
+		pool.QuoteMint.String() != accounts[4] || !pool.OpenTime.IsZero() || pool.InitBaseReserve != 0 {
+		t.Fatalf("unexpected pool %+v", pool)
+	}
+
+	if _, err := ParseCreation(Clmm, accounts[:5], base58.Encode(raw)); err == nil {
+		t.Fatal("expected an error for a truncated account list")
+	}
+}
+
+func TestParseCreationUnknownType(t *testing.T) {
+	if _, err := ParseCreation("stable", accountStrings(21), encodeInitialize2(1, 0, 1, 1)); err == nil {
+		t.Fatal("expected an error for an unknown pool type")
+	}
+}
+
+func TestIsCreationLog(t *testing.T) {
+	tests := []struct {
+		poolType PoolType
+		logs     []string
+		want     bool
+	}{
+		{AmmV4, []string{"Program log: initialize2: InitializeInstruction2 { nonce: 254, open_time: 0 }"}, true},
+		{AmmV4, []string{"Program log: ray_log: A0BCDE"}, false},
+		{Cpmm, []string{"Program log: Instruction: InitializeAccount3", "Program log: Instruction: Initialize"}, true},
+		{Cpmm, []string{"Program log: Instruction: InitializeAccount3", "Program log: Instruction: SwapBaseInput"}, false},
+		{Clmm, []string{"Program log: Instruction: CreatePool"}, true},
+		{Clmm, []string{"Program log: Instruction: OpenPositionV2"}, false},
+	}
+	for _, tt := range tests {
+		if got := IsCreationLog(tt.poolType, tt.logs); got != tt.want {
+			t.Errorf("%s %q: got %v, want %v", tt.poolType, tt.logs, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package raydium
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/serum"
+)
+
+func newKeys(n int) []solana.PublicKey {
+	keys := make([]solana.PublicKey, n)
+	for i := range keys {
+		keys[i] = solana.NewWallet().PublicKey()
+	}
+	return keys
+}
+
+func TestPoolKeysFromInitialize2(t *testing.T) {
+	keys := newKeys(21)
+	accounts := make([]string, len(keys))
+	for i, key := range keys {
+		accounts[i] = key.String()
+	}
+
+	pool, err := PoolKeysFromInitialize2(accounts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pool.ID != keys[4] || pool.BaseMint != keys[8] || pool.QuoteMint != keys[9] ||
+		pool.BaseVault != keys[10] || pool.QuoteVault != keys[11] || pool.TargetOrders != keys[12] ||
+		pool.MarketProgramID != keys[15] || pool.MarketID != keys[16] {
+		t.Fatalf("unexpected pool keys %+v", pool)
+	}
+
+	if _, err := PoolKeysFromInitialize2(accounts[:10]); err == nil {
+		t.Fatal("expected an error for a truncated account list")
+	}
+	accounts[4] = "not a key"
+	if _, err := PoolKeysFromInitialize2(accounts); err == nil {
+		t.Fatal("expected an error for an invalid account")
+	}
+}
+
+func TestDecodeMarket(t *testing.T) {
+	keys := newKeys(7)
+	pool := &PoolKeys{MarketID: keys[0], MarketProgramID: keys[1]}
+
+	// Not every nonce yields an off-curve address; use the first that does.
+	var nonce uint64
+	var vaultSigner solana.PublicKey
+	for ; ; nonce++ {
+		seed := make([]byte, 8)
+		binary.LittleEndian.PutUint64(seed, nonce)
+		var err error
+		if vaultSigner, err = solana.CreateProgramAddress([][]byte{pool.MarketID.Bytes(), seed}, pool.MarketProgramID); err == nil {
+			break
+		}
+	}
+
+	market := serum.MarketV2{
+		OwnAddress:       pool.MarketID,
+		VaultSignerNonce: bin.Uint64(nonce),
+		BaseVault:        keys[2],
+		QuoteVault:       keys[3],
+		EventQueue:       keys[4],
+		Bids:             keys[5],
+		Asks:             keys[6],
+	}
+	var buf bytes.Buffer
+	if err := bin.NewBinEncoder(&buf).Encode(&market); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := DecodeMarket(pool, buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := MarketKeys{Bids: keys[5], Asks: keys[6], EventQueue: keys[4], BaseVault: keys[2], QuoteVault: keys[3], VaultSigner: vaultSigner}
+	if *got != want {
+		t.Fatalf("got %+v, want %+v", *got, want)
+	}
+}
+
+func TestAmountOut(t *testing.T) {
+	tests := []struct {
+		name                            string
+		amountIn, reserveIn, reserveOut uint64
+		want                            uint64
+	}{
+		// 1 SOL into 100 SOL / 1,000,000 tokens: 0.9975 * 1e6 / 100.9975.
+		{"small buy", 1_000_000_000, 100_000_000_000, 1_000_000_000_000, 9_876_482_091},
+		{"empty pool", 1_000_000_000, 0, 1_000_000_000_000, 0},
+		{"no input", 0, 100_000_000_000, 1_000_000_000_000, 0},
+		{"no overflow", 1 << 62, 1 << 62, 1 << 62, 2302957098063238765},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AmountOut(tt.amountIn, tt.reserveIn, tt.reserveOut); got != tt.want {
+				t.Fatalf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMinAmountOut(t *testing.T) {
+	if got := MinAmountOut(1_000_000, 200); got != 980_000 {
+		t.Fatalf("got %d, want 980000", got)
+	}
+	if got := MinAmountOut(1_000_000, 0); got != 1_000_000 {
+		t.Fatalf("got %d, want 1000000", got)
+	}
+}
+
+func TestTokenAccountAmount(t *testing.T) {
+	data := make([]byte, 165)
+	binary.LittleEndian.PutUint64(data[64:], 123456789)
+	if got, err := TokenAccountAmount(data); err != nil || got != 123456789 {
+		t.Fatalf("got (%d, %v), want 123456789", got, err)
+	}
+	if _, err := TokenAccountAmount(data[:40]); err == nil {
+		t.Fatal("expected an error for short account data")
+	}
+}
+
+func TestNewSwapBaseInInstruction(t *testing.T) {
+	keys := newKeys(14)
+	pool := &PoolKeys{ID: keys[0], Authority: keys[1], OpenOrders: keys[2], TargetOrders: keys[3], BaseVault: keys[4], QuoteVault: keys[5], MarketProgramID: keys[6], MarketID: keys[7]}
+	market := &MarketKeys{Bids: keys[8], Asks: keys[9], EventQueue: keys[10], BaseVault: keys[11], QuoteVault: keys[12], VaultSigner: keys[13]}
+	source, destination, owner := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+
+	inst := NewSwapBaseInInstruction(pool, market, source, destination, owner, 10_000_000, 9_800_000)
+	if inst.ProgramID() != AmmV4ProgramID {
+		t.Fatalf("program id %s, want %s", inst.ProgramID(), AmmV4ProgramID)
+	}
+	data, err := inst.Data()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 17 || data[0] != 9 ||
+		binary.LittleEndian.Uint64(data[1:9]) != 10_000_000 || binary.LittleEndian.Uint64(data[9:17]) != 9_800_000 {
+		t.Fatalf("unexpected instruction data %v", data)
+	}
+
+	accounts := inst.Accounts()
+	if len(accounts) != 18 {
+		t.Fatalf("got %d accounts, want 18", len(accounts))
+	}
+	if accounts[0].PublicKey != solana.TokenProgramID || accounts[1].PublicKey != pool.ID || !accounts[1].IsWritable {
+		t.Fatalf("unexpected leading accounts %v", accounts[:2])
+	}
+	if accounts[14].PublicKey != market.VaultSigner || accounts[15].PublicKey != source || accounts[16].PublicKey != destination {
+		t.Fatalf("unexpected market and user accounts %v", accounts[14:17])
+	}
+	if last := accounts[17]; last.PublicKey != owner || !last.IsSigner || last.IsWritable {
+		t.Fatalf("owner must be the only signer, got %+v", last)
+	}
+}
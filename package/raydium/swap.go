@@ -0,0 +1,79 @@
+package raydium
+
+import (
+	"encoding/binary"
+	"math/big"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// Fee charged by AMM v4 pools on the input amount: 25 / 10000 = 0.25%.
+const (
+	FeeNumerator   = 25
+	FeeDenominator = 10000
+)
+
+const swapBaseInDiscriminator = 9
+
+// AmountOut returns the output of swapping amountIn into a constant product
+// pool holding reserveIn and reserveOut, after the pool fee.
+func AmountOut(amountIn, reserveIn, reserveOut uint64) uint64 {
+	if reserveIn == 0 || reserveOut == 0 {
+		return 0
+	}
+	in := new(big.Int).SetUint64(amountIn)
+	in.Mul(in, big.NewInt(FeeDenominator-FeeNumerator))
+	in.Quo(in, big.NewInt(FeeDenominator))
+
+	out := new(big.Int).Mul(in, new(big.Int).SetUint64(reserveOut))
+	out.Quo(out, in.Add(in, new(big.Int).SetUint64(reserveIn)))
+	return out.Uint64()
+}
+
+// PriceImpact returns the fraction by which swapping amountIn moves the pool
+// price, e.g. 0.05 for 5%.
+func PriceImpact(amountIn, reserveIn uint64) float64 {
+	if amountIn == 0 {
+		return 0
+	}
+	return float64(amountIn) / (float64(reserveIn) + float64(amountIn))
+}
+
+// MinAmountOut returns amountOut reduced by slippageBps.
+func MinAmountOut(amountOut uint64, slippageBps int) uint64 {
+	out := new(big.Int).SetUint64(amountOut)
+	out.Mul(out, big.NewInt(int64(10000-slippageBps)))
+	return out.Quo(out, big.NewInt(10000)).Uint64()
+}
+
+// NewSwapBaseInInstruction builds a swapBaseIn instruction selling exactly
+// amountIn from source for at least minAmountOut into destination. Both token
+// accounts must belong to owner, who signs the transaction.
+func NewSwapBaseInInstruction(pool *PoolKeys, market *MarketKeys, source, destination, owner solana.PublicKey, amountIn, minAmountOut uint64) solana.Instruction {
+	data := make([]byte, 17)
+	data[0] = swapBaseInDiscriminator
+	binary.LittleEndian.PutUint64(data[1:9], amountIn)
+	binary.LittleEndian.PutUint64(data[9:17], minAmountOut)
+
+	accounts := solana.AccountMetaSlice{
+		solana.Meta(solana.TokenProgramID),
+		solana.Meta(pool.ID).WRITE(),
+		solana.Meta(pool.Authority),
+		solana.Meta(pool.OpenOrders).WRITE(),
+		solana.Meta(pool.TargetOrders).WRITE(),
+		solana.Meta(pool.BaseVault).WRITE(),
+		solana.Meta(pool.QuoteVault).WRITE(),
+		solana.Meta(pool.MarketProgramID),
+		solana.Meta(pool.MarketID).WRITE(),
+		solana.Meta(market.Bids).WRITE(),
+		solana.Meta(market.Asks).WRITE(),
+		solana.Meta(market.EventQueue).WRITE(),
+		solana.Meta(market.BaseVault).WRITE(),
+		solana.Meta(market.QuoteVault).WRITE(),
+		solana.Meta(market.VaultSigner),
+		solana.Meta(source).WRITE(),
+		solana.Meta(destination).WRITE(),
+		solana.Meta(owner).SIGNER(),
+	}
+	return solana.NewInstruction(AmmV4ProgramID, accounts, data)
+}
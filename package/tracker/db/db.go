@@ -0,0 +1,160 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/low4ey/sniper/internal/config"
+	_ "modernc.org/sqlite" // registers the "sqlite" driver
+)
+
+var (
+	mu   sync.Mutex
+	conn *sql.DB
+)
+
+// migrations are applied in order; PRAGMA user_version records how many of
+// them have already run against a database file. Append, never edit.
+var migrations = []string{
+	`CREATE TABLE IF NOT EXISTS tokens (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		time INTEGER NOT NULL,
+		name TEXT NOT NULL,
+		mint TEXT NOT NULL,
+		creator TEXT NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_tokens_mint ON tokens (mint);
+	CREATE INDEX IF NOT EXISTS idx_tokens_name ON tokens (name);
+	CREATE INDEX IF NOT EXISTS idx_tokens_creator ON tokens (creator);`,
+
+	`CREATE TABLE IF NOT EXISTS holdings (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		time INTEGER NOT NULL,
+		token TEXT NOT NULL,
+		tokenName TEXT NOT NULL,
+		balance REAL NOT NULL,
+		solPaid REAL NOT NULL,
+		solFeePaid REAL NOT NULL,
+		solPaidUSDC REAL NOT NULL,
+		solFeePaidUSDC REAL NOT NULL,
+		perTokenPaidUSDC REAL NOT NULL,
+		slot INTEGER NOT NULL,
+		program TEXT NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_holdings_token ON holdings (token);`,
+
+	`CREATE TABLE IF NOT EXISTS paper_holdings (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		time INTEGER NOT NULL,
+		token TEXT NOT NULL,
+		tokenName TEXT NOT NULL,
+		balance REAL NOT NULL,
+		solPaid REAL NOT NULL,
+		solFeePaid REAL NOT NULL,
+		solPaidUSDC REAL NOT NULL,
+		solFeePaidUSDC REAL NOT NULL,
+		perTokenPaidUSDC REAL NOT NULL,
+		slot INTEGER NOT NULL,
+		program TEXT NOT NULL,
+		soldTime INTEGER,
+		solReceived REAL
+	);
+	CREATE INDEX IF NOT EXISTS idx_paper_holdings_token ON paper_holdings (token);`,
+
+	`CREATE TABLE IF NOT EXISTS simulations (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		time INTEGER NOT NULL,
+		signature TEXT NOT NULL,
+		token TEXT NOT NULL,
+		side TEXT NOT NULL,
+		unitsConsumed INTEGER NOT NULL,
+		err TEXT NOT NULL,
+		logs TEXT NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_simulations_signature ON simulations (signature);`,
+}
+
+// Open opens (creating if needed) the SQLite database at path, brings its
+// schema up to date and makes it the database used by this package.
+func Open(path string) error {
+	mu.Lock()
+	defer mu.Unlock()
+	return open(path)
+}
+
+// Close closes the database opened by Open, if any.
+func Close() error {
+	mu.Lock()
+	defer mu.Unlock()
+	if conn == nil {
+		return nil
+	}
+	err := conn.Close()
+	conn = nil
+	return err
+}
+
+func open(path string) error {
+	if conn != nil {
+		if err := conn.Close(); err != nil {
+			return err
+		}
+		conn = nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create database directory: %v", err)
+	}
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return err
+	}
+	// SQLite allows a single writer; serialize access through one connection.
+	db.SetMaxOpenConns(1)
+	if err := migrate(db); err != nil {
+		db.Close()
+		return err
+	}
+	conn = db
+	return nil
+}
+
+// getDB returns the open database, lazily opening the configured holdings
+// database on first use.
+func getDB() (*sql.DB, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if conn == nil {
+		if err := open(config.Get().Swap.DbNameTrackerHoldings); err != nil {
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+func migrate(db *sql.DB) error {
+	var version int
+	if err := db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return fmt.Errorf("failed to read schema version: %v", err)
+	}
+	for i := version; i < len(migrations); i++ {
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(migrations[i]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d failed: %v", i+1, err)
+		}
+		if _, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", i+1)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d failed: %v", i+1, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,246 @@
+package db
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/low4ey/sniper/package/models"
+	_ "modernc.org/sqlite"
+)
+
+func openTempDB(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "tracker", "holdings.db")
+	if err := Open(path); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { Close() })
+	return path
+}
+
+func TestOpenMigratesSchema(t *testing.T) {
+	path := openTempDB(t)
+	if err := Close(); err != nil {
+		t.Fatal(err)
+	}
+	// Re-opening an up to date database must not re-run migrations.
+	if err := Open(path); err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer raw.Close()
+
+	var version int
+	if err := raw.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		t.Fatal(err)
+	}
+	if version != len(migrations) {
+		t.Fatalf("got schema version %d, want %d", version, len(migrations))
+	}
+	for _, table := range []string{"tokens", "holdings", "paper_holdings", "simulations"} {
+		var name string
+		if err := raw.QueryRow("SELECT name FROM sqlite_master WHERE type = 'table' AND name = ?", table).Scan(&name); err != nil {
+			t.Fatalf("table %s missing: %v", table, err)
+		}
+	}
+}
+
+func TestTokens(t *testing.T) {
+	openTempDB(t)
+
+	tokens := []models.NewTokenRecord{
+		{Time: 1, Name: "Alpha", Mint: "mintA", Creator: "creator1"},
+		{Time: 2, Name: "Beta", Mint: "mintB", Creator: "creator2"},
+		{Time: 3, Name: "Gamma", Mint: "mintC", Creator: "creator1"},
+	}
+	for _, token := range tokens {
+		if err := InsertNewToken(token); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	byMint, err := SelectTokenByMint("mintB")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(byMint) != 1 || byMint[0].Name != "Beta" || byMint[0].Time != 2 || byMint[0].ID == nil {
+		t.Fatalf("unexpected tokens for mintB: %+v", byMint)
+	}
+
+	// Matches on creator only.
+	dupes, err := SelectTokenByNameAndCreator("Delta", "creator1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dupes) != 2 {
+		t.Fatalf("got %d tokens for creator1, want 2", len(dupes))
+	}
+
+	// Matches on name only.
+	dupes, err = SelectTokenByNameAndCreator("Beta", "creator9")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dupes) != 1 || dupes[0].Mint != "mintB" {
+		t.Fatalf("unexpected tokens for name Beta: %+v", dupes)
+	}
+
+	none, err := SelectTokenByMint("unknown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(none) != 0 {
+		t.Fatalf("got %d tokens for unknown mint, want 0", len(none))
+	}
+}
+
+func TestHoldings(t *testing.T) {
+	path := openTempDB(t)
+
+	holding := models.HoldingRecord{
+		Time:             1700000000,
+		Token:            "mintA",
+		TokenName:        "Alpha",
+		Balance:          1234.5,
+		SolPaid:          0.01,
+		SolFeePaid:       5000,
+		SolPaidUSDC:      2.3,
+		SolFeePaidUSDC:   0.001,
+		PerTokenPaidUSDC: 0.0018,
+		Slot:             42,
+		Program:          "RAYDIUM",
+	}
+	if err := InsertHolding(holding); err != nil {
+		t.Fatal(err)
+	}
+	if err := InsertHolding(models.HoldingRecord{Token: "mintB", TokenName: "Beta"}); err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer raw.Close()
+
+	var got models.HoldingRecord
+	err = raw.QueryRow(`SELECT time, token, tokenName, balance, solPaid, solFeePaid, solPaidUSDC, solFeePaidUSDC, perTokenPaidUSDC, slot, program
+		FROM holdings WHERE token = ?`, "mintA").Scan(
+		&got.Time, &got.Token, &got.TokenName, &got.Balance, &got.SolPaid, &got.SolFeePaid,
+		&got.SolPaidUSDC, &got.SolFeePaidUSDC, &got.PerTokenPaidUSDC, &got.Slot, &got.Program,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != holding {
+		t.Fatalf("got holding %+v, want %+v", got, holding)
+	}
+
+	all, err := SelectAllHoldings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 2 || all[0].ID == nil {
+		t.Fatalf("got %d holdings, want 2", len(all))
+	}
+	all[1].ID = nil
+	if all[1] != holding {
+		t.Fatalf("got holding %+v, want %+v", all[1], holding)
+	}
+
+	if err := RemoveHolding("mintA"); err != nil {
+		t.Fatal(err)
+	}
+	var count int
+	if err := raw.QueryRow("SELECT COUNT(*) FROM holdings").Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Fatalf("got %d holdings after removal, want 1", count)
+	}
+}
+
+func TestPaperHoldings(t *testing.T) {
+	openTempDB(t)
+
+	if err := InsertHolding(models.HoldingRecord{Token: "mintA", TokenName: "Alpha"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, holding := range []models.HoldingRecord{
+		{Time: 1, Token: "mintA", TokenName: "Alpha", Balance: 100, SolPaid: 0.01},
+		{Time: 2, Token: "mintB", TokenName: "Beta", Balance: 200, SolPaid: 0.02},
+	} {
+		if err := InsertPaperHolding(holding); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	unsold, err := SelectOpenPaperHoldings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unsold) != 2 || unsold[0].Token != "mintA" || unsold[0].Balance != 100 {
+		t.Fatalf("unexpected unsold paper holdings: %+v", unsold)
+	}
+
+	if err := ClosePaperHolding("mintA", 3, 0.015); err != nil {
+		t.Fatal(err)
+	}
+	unsold, err = SelectOpenPaperHoldingsByMint("mintA")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unsold) != 0 {
+		t.Fatalf("got %d unsold paper holdings for mintA after close, want 0", len(unsold))
+	}
+	unsold, err = SelectOpenPaperHoldings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unsold) != 1 || unsold[0].Token != "mintB" {
+		t.Fatalf("unexpected unsold paper holdings after close: %+v", unsold)
+	}
+
+	// Real holdings are untouched by paper trades.
+	holdings, err := SelectAllHoldings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(holdings) != 1 {
+		t.Fatalf("got %d real holdings, want 1", len(holdings))
+	}
+}
+
+func TestSimulations(t *testing.T) {
+	openTempDB(t)
+
+	simulation := models.SimulationRecord{
+		Time:          1700000000,
+		Signature:     "sig1",
+		Token:         "mintA",
+		Side:          "buy",
+		UnitsConsumed: 84000,
+		Err:           `{"InstructionError":[3,{"Custom":6001}]}`,
+		Logs:          "Program log: one\nProgram log: two",
+	}
+	if err := InsertSimulation(simulation); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := SelectSimulationsBySignature("sig1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].ID == nil {
+		t.Fatalf("got %d simulations, want 1", len(got))
+	}
+	got[0].ID = nil
+	if got[0] != simulation {
+		t.Fatalf("got simulation %+v, want %+v", got[0], simulation)
+	}
+}
@@ -0,0 +1,62 @@
+package db
+
+import "github.com/low4ey/sniper/package/models"
+
+// InsertHolding records a token bought by the sniper.
+func InsertHolding(holding models.HoldingRecord) error {
+	db, err := getDB()
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(
+		`INSERT INTO holdings (time, token, tokenName, balance, solPaid, solFeePaid, solPaidUSDC, solFeePaidUSDC, perTokenPaidUSDC, slot, program)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		holding.Time, holding.Token, holding.TokenName, holding.Balance, holding.SolPaid, holding.SolFeePaid,
+		holding.SolPaidUSDC, holding.SolFeePaidUSDC, holding.PerTokenPaidUSDC, holding.Slot, holding.Program,
+	)
+	return err
+}
+
+// RemoveHolding deletes every holding of the given token mint.
+func RemoveHolding(tokenMint string) error {
+	db, err := getDB()
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec("DELETE FROM holdings WHERE token = ?", tokenMint)
+	return err
+}
+
+// SelectAllHoldings returns every open holding, oldest first.
+func SelectAllHoldings() ([]models.HoldingRecord, error) {
+	return selectHoldings(`SELECT id, time, token, tokenName, balance, solPaid, solFeePaid, solPaidUSDC, solFeePaidUSDC, perTokenPaidUSDC, slot, program
+		FROM holdings ORDER BY time, id`)
+}
+
+func selectHoldings(query string, args ...interface{}) ([]models.HoldingRecord, error) {
+	db, err := getDB()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var holdings []models.HoldingRecord
+	for rows.Next() {
+		var (
+			holding models.HoldingRecord
+			id      int
+		)
+		err := rows.Scan(&id, &holding.Time, &holding.Token, &holding.TokenName, &holding.Balance, &holding.SolPaid, &holding.SolFeePaid,
+			&holding.SolPaidUSDC, &holding.SolFeePaidUSDC, &holding.PerTokenPaidUSDC, &holding.Slot, &holding.Program)
+		if err != nil {
+			return nil, err
+		}
+		holding.ID = &id
+		holdings = append(holdings, holding)
+	}
+	return holdings, rows.Err()
+}
@@ -0,0 +1,46 @@
+package db
+
+import "github.com/low4ey/sniper/package/models"
+
+// Paper holdings are the synthetic fills recorded in simulation mode. They are
+// kept apart from real holdings and closed instead of deleted on sell, so the
+// outcome of every paper trade stays available for evaluation.
+
+// InsertPaperHolding records a simulated buy.
+func InsertPaperHolding(holding models.HoldingRecord) error {
+	db, err := getDB()
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(
+		`INSERT INTO paper_holdings (time, token, tokenName, balance, solPaid, solFeePaid, solPaidUSDC, solFeePaidUSDC, perTokenPaidUSDC, slot, program)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		holding.Time, holding.Token, holding.TokenName, holding.Balance, holding.SolPaid, holding.SolFeePaid,
+		holding.SolPaidUSDC, holding.SolFeePaidUSDC, holding.PerTokenPaidUSDC, holding.Slot, holding.Program,
+	)
+	return err
+}
+
+// ClosePaperHolding marks every open paper holding of tokenMint as sold at
+// soldTime for solReceived SOL, net of fees.
+func ClosePaperHolding(tokenMint string, soldTime int, solReceived float64) error {
+	db, err := getDB()
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec("UPDATE paper_holdings SET soldTime = ?, solReceived = ? WHERE token = ? AND soldTime IS NULL", soldTime, solReceived, tokenMint)
+	return err
+}
+
+// SelectOpenPaperHoldings returns every paper holding that was not sold yet,
+// oldest first.
+func SelectOpenPaperHoldings() ([]models.HoldingRecord, error) {
+	return selectHoldings(`SELECT id, time, token, tokenName, balance, solPaid, solFeePaid, solPaidUSDC, solFeePaidUSDC, perTokenPaidUSDC, slot, program
+		FROM paper_holdings WHERE soldTime IS NULL ORDER BY time, id`)
+}
+
+// SelectOpenPaperHoldingsByMint returns the open paper holdings of tokenMint.
+func SelectOpenPaperHoldingsByMint(tokenMint string) ([]models.HoldingRecord, error) {
+	return selectHoldings(`SELECT id, time, token, tokenName, balance, solPaid, solFeePaid, solPaidUSDC, solFeePaidUSDC, perTokenPaidUSDC, slot, program
+		FROM paper_holdings WHERE token = ? AND soldTime IS NULL ORDER BY time, id`, tokenMint)
+}
@@ -0,0 +1,49 @@
+package db
+
+import "github.com/low4ey/sniper/package/models"
+
+// InsertSimulation records the pre-send simulation of a buy or sell.
+func InsertSimulation(simulation models.SimulationRecord) error {
+	db, err := getDB()
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(
+		`INSERT INTO simulations (time, signature, token, side, unitsConsumed, err, logs)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		simulation.Time, simulation.Signature, simulation.Token, simulation.Side,
+		simulation.UnitsConsumed, simulation.Err, simulation.Logs,
+	)
+	return err
+}
+
+// SelectSimulationsBySignature returns the simulations recorded for a
+// transaction signature.
+func SelectSimulationsBySignature(signature string) ([]models.SimulationRecord, error) {
+	db, err := getDB()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.Query(`SELECT id, time, signature, token, side, unitsConsumed, err, logs
+		FROM simulations WHERE signature = ? ORDER BY time, id`, signature)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var simulations []models.SimulationRecord
+	for rows.Next() {
+		var (
+			simulation models.SimulationRecord
+			id         int
+		)
+		err := rows.Scan(&id, &simulation.Time, &simulation.Signature, &simulation.Token, &simulation.Side,
+			&simulation.UnitsConsumed, &simulation.Err, &simulation.Logs)
+		if err != nil {
+			return nil, err
+		}
+		simulation.ID = &id
+		simulations = append(simulations, simulation)
+	}
+	return simulations, rows.Err()
+}
@@ -0,0 +1,53 @@
+package db
+
+import "github.com/low4ey/sniper/package/models"
+
+// InsertNewToken records a token seen by the rug check.
+func InsertNewToken(token models.NewTokenRecord) error {
+	db, err := getDB()
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(
+		"INSERT INTO tokens (time, name, mint, creator) VALUES (?, ?, ?, ?)",
+		token.Time, token.Name, token.Mint, token.Creator,
+	)
+	return err
+}
+
+// SelectTokenByNameAndCreator returns the tokens that share either the name or
+// the creator with a new token.
+func SelectTokenByNameAndCreator(name, creator string) ([]models.NewTokenRecord, error) {
+	return selectTokens("SELECT id, time, name, mint, creator FROM tokens WHERE name = ? OR creator = ?", name, creator)
+}
+
+// SelectTokenByMint returns the tokens recorded for a mint.
+func SelectTokenByMint(mint string) ([]models.NewTokenRecord, error) {
+	return selectTokens("SELECT id, time, name, mint, creator FROM tokens WHERE mint = ?", mint)
+}
+
+func selectTokens(query string, args ...interface{}) ([]models.NewTokenRecord, error) {
+	db, err := getDB()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokens []models.NewTokenRecord
+	for rows.Next() {
+		var (
+			token models.NewTokenRecord
+			id    int
+		)
+		if err := rows.Scan(&id, &token.Time, &token.Name, &token.Mint, &token.Creator); err != nil {
+			return nil, err
+		}
+		token.ID = &id
+		tokens = append(tokens, token)
+	}
+	return tokens, rows.Err()
+}
@@ -0,0 +1,88 @@
+package transactions
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
+	"github.com/low4ey/sniper/internal/config"
+	envinit "github.com/low4ey/sniper/internal/init"
+	"github.com/low4ey/sniper/package/httpclient"
+	"github.com/low4ey/sniper/package/jupiter"
+	"github.com/low4ey/sniper/package/price"
+)
+
+// rugCheckURL is the rugcheck.xyz token report endpoint.
+const rugCheckURL = "https://api.rugcheck.xyz/v1/tokens/%s/report"
+
+// Client runs the sniper's transactions against the endpoints and wallet of
+// an EnvConfig.
+type Client struct {
+	env        *envinit.EnvConfig
+	wallet     solana.PrivateKey
+	rpcClient  *rpc.Client
+	httpClient *http.Client
+	solPrice   *price.Jupiter
+	quotes     *jupiter.QuoteClient
+}
+
+// NewHTTPClient builds the HTTP client shared by every API call, rate limited
+// per host as configured in TxConfig.
+func NewHTTPClient(env *envinit.EnvConfig) *httpclient.Client {
+	tx := config.Get().Tx
+	limits := make(map[string]float64)
+	for _, service := range []struct {
+		rps       int
+		endpoints []string
+	}{
+		{tx.HeliusRateLimit, []string{env.HeliusHTTPSURI, env.HeliusHTTPSURITx}},
+		{tx.JupiterRateLimit, []string{env.JupHTTPSQuoteURI, env.JupHTTPSSwapURI, env.JupHTTPSPriceURI}},
+		{tx.RugCheckRateLimit, []string{rugCheckURL}},
+		{tx.DexscreenerRateLimit, []string{env.DexHTTPSLatestTokens, env.DexHTTPSTokenPairs}},
+	} {
+		for _, endpoint := range service.endpoints {
+			if u, err := url.Parse(endpoint); err == nil && u.Hostname() != "" {
+				limits[u.Hostname()] = float64(service.rps)
+			}
+		}
+	}
+	return httpclient.New(httpclient.Options{
+		Timeout:    time.Duration(tx.GetTimeout) * time.Millisecond,
+		MaxRetries: tx.HTTPMaxRetries,
+		BaseDelay:  time.Duration(tx.RetryDelay) * time.Millisecond,
+		MaxDelay:   time.Duration(tx.HTTPMaxRetryDelay) * time.Millisecond,
+		RateLimits: limits,
+	})
+}
+
+// NewClient builds a Client from env, sending API calls through httpClient.
+// The wallet is optional; without it only the read-only functions work.
+func NewClient(env *envinit.EnvConfig, httpClient *http.Client) (*Client, error) {
+	c := &Client{
+		env:        env,
+		rpcClient:  rpc.NewWithCustomRPCClient(jsonrpc.NewClientWithOpts(env.HeliusHTTPSURI, &jsonrpc.RPCClientOpts{HTTPClient: httpClient})),
+		httpClient: httpClient,
+		solPrice:   price.NewJupiter(env.JupHTTPSPriceURI, httpClient),
+		quotes:     jupiter.NewQuoteClient(env.JupHTTPSQuoteURI, httpClient),
+	}
+	if env.PrivKeyWallet != "" {
+		wallet, err := loadWallet(env.PrivKeyWallet)
+		if err != nil {
+			return nil, err
+		}
+		c.wallet = wallet
+	}
+	return c, nil
+}
+
+// requireWallet returns the signing wallet or an error if none is configured.
+func (c *Client) requireWallet() (solana.PrivateKey, error) {
+	if c.wallet == nil {
+		return nil, fmt.Errorf("PRIV_KEY_WALLET is not configured")
+	}
+	return c.wallet, nil
+}
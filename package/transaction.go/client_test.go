@@ -0,0 +1,97 @@
+package transactions
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/low4ey/sniper/internal/config"
+	envinit "github.com/low4ey/sniper/internal/init"
+)
+
+// newRPCStandIn serves JSON-RPC requests with the result respond returns for
+// their method, and returns the server URL.
+func newRPCStandIn(t *testing.T, respond func(method string, params json.RawMessage) interface{}) string {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result":  respond(req.Method, req.Params),
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestNewClientFromEnvConfig(t *testing.T) {
+	cfg := config.ConfigVal
+	cfg.RugCheck.SimulationMode = false
+	config.Set(&cfg)
+	t.Cleanup(func() { config.Set(nil) })
+
+	wallet := solana.NewWallet().PrivateKey
+	mint := solana.NewWallet().PublicKey()
+	var owner string
+	rpcURL := newRPCStandIn(t, func(method string, params json.RawMessage) interface{} {
+		var args []json.RawMessage
+		json.Unmarshal(params, &args)
+		if method != "getTokenAccountsByOwner" || len(args) == 0 {
+			t.Errorf("unexpected call %s %s", method, params)
+			return nil
+		}
+		json.Unmarshal(args[0], &owner)
+		account := func(amount string) map[string]interface{} {
+			return map[string]interface{}{
+				"pubkey": solana.NewWallet().PublicKey().String(),
+				"account": map[string]interface{}{
+					"data": map[string]interface{}{
+						"program": "spl-token",
+						"parsed":  map[string]interface{}{"type": "account", "info": map[string]interface{}{"tokenAmount": map[string]string{"amount": amount}}},
+						"space":   165,
+					},
+					"executable": false,
+					"lamports":   2039280,
+					"owner":      solana.TokenProgramID.String(),
+					"rentEpoch":  0,
+				},
+			}
+		}
+		return map[string]interface{}{
+			"context": map[string]int{"slot": 1},
+			"value":   []interface{}{account("1500"), account("250")},
+		}
+	})
+
+	env := &envinit.EnvConfig{PrivKeyWallet: wallet.String(), HeliusHTTPSURI: rpcURL}
+	c, err := NewClient(env, http.DefaultClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	balance, err := c.TokenBalance(context.Background(), mint.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance != 1750 {
+		t.Fatalf("balance %d, want 1750", balance)
+	}
+	if owner != wallet.PublicKey().String() {
+		t.Fatalf("balance requested for %s, want the configured wallet %s", owner, wallet.PublicKey())
+	}
+
+	if _, err := NewClient(&envinit.EnvConfig{PrivKeyWallet: "not a key", HeliusHTTPSURI: rpcURL}, http.DefaultClient); err == nil {
+		t.Fatal("expected an error for a malformed wallet")
+	}
+}
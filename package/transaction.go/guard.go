@@ -0,0 +1,72 @@
+package transactions
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/low4ey/sniper/internal/config"
+	"github.com/low4ey/sniper/package/models"
+)
+
+// ---------- Quote Guard ----------
+
+// guardQuote checks a buy quote against the price impact, route hop and AMM
+// limits of swap, reporting every violation.
+func guardQuote(quote *models.QuoteResponse, swap config.SwapConfig) error {
+	return errors.Join(
+		checkPriceImpact(quote, swap.MaxPriceImpactBps),
+		checkRouteHops(quote, swap.MaxRouteHops),
+		checkAmms(quote, swap.AllowAmms, swap.DenyAmms),
+	)
+}
+
+// checkPriceImpact rejects a quote whose price impact exceeds maxBps. A
+// maxBps of 0 disables the check.
+func checkPriceImpact(quote *models.QuoteResponse, maxBps int) error {
+	if maxBps > 0 && quote.PriceImpactBps() > float64(maxBps) {
+		return fmt.Errorf("price impact %.2f%% exceeds the maximum of %.2f%%", quote.PriceImpactPct*100, float64(maxBps)/100)
+	}
+	return nil
+}
+
+// checkRouteHops rejects a quote routed through more than maxHops swaps. Legs
+// of a split route that swap the same pair count as one hop. A maxHops of 0
+// disables the check.
+func checkRouteHops(quote *models.QuoteResponse, maxHops int) error {
+	if maxHops <= 0 {
+		return nil
+	}
+	hops := make(map[[2]string]bool)
+	for _, step := range quote.RoutePlan {
+		hops[[2]string{step.SwapInfo.InputMint, step.SwapInfo.OutputMint}] = true
+	}
+	if len(hops) > maxHops {
+		return fmt.Errorf("route has %d hops, more than the maximum of %d", len(hops), maxHops)
+	}
+	return nil
+}
+
+// checkAmms rejects a quote routed through an AMM that is denied or, when
+// allow is not empty, not allowed. Labels are compared case-insensitively.
+func checkAmms(quote *models.QuoteResponse, allow, deny []string) error {
+	for _, step := range quote.RoutePlan {
+		label := step.SwapInfo.Label
+		if containsFold(deny, label) {
+			return fmt.Errorf("route uses denied AMM %q", label)
+		}
+		if len(allow) > 0 && !containsFold(allow, label) {
+			return fmt.Errorf("route uses AMM %q, which is not allowed", label)
+		}
+	}
+	return nil
+}
+
+func containsFold(slice []string, item string) bool {
+	for _, s := range slice {
+		if strings.EqualFold(s, item) {
+			return true
+		}
+	}
+	return false
+}
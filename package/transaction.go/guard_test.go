@@ -0,0 +1,57 @@
+package transactions
+
+import (
+	"testing"
+
+	"github.com/low4ey/sniper/internal/config"
+	"github.com/low4ey/sniper/package/models"
+)
+
+func routeStep(label, inputMint, outputMint string) models.RoutePlan {
+	return models.RoutePlan{SwapInfo: models.SwapInfo{Label: label, InputMint: inputMint, OutputMint: outputMint}, Percent: 100}
+}
+
+func TestGuardQuote(t *testing.T) {
+	direct := models.QuoteResponse{
+		PriceImpactPct: 0.01,
+		RoutePlan:      []models.RoutePlan{routeStep("Raydium", "SOL", "TOKEN")},
+	}
+	split := models.QuoteResponse{
+		PriceImpactPct: 0.01,
+		RoutePlan: []models.RoutePlan{
+			routeStep("Raydium", "SOL", "TOKEN"),
+			routeStep("Orca", "SOL", "TOKEN"),
+		},
+	}
+	twoHops := models.QuoteResponse{
+		PriceImpactPct: 0.01,
+		RoutePlan: []models.RoutePlan{
+			routeStep("Meteora", "SOL", "USDC"),
+			routeStep("Raydium", "USDC", "TOKEN"),
+		},
+	}
+
+	tests := []struct {
+		name    string
+		quote   models.QuoteResponse
+		swap    config.SwapConfig
+		wantErr bool
+	}{
+		{"no limits", twoHops, config.SwapConfig{}, false},
+		{"impact below max", direct, config.SwapConfig{MaxPriceImpactBps: 200}, false},
+		{"impact above max", direct, config.SwapConfig{MaxPriceImpactBps: 50}, true},
+		{"split route is one hop", split, config.SwapConfig{MaxRouteHops: 1}, false},
+		{"too many hops", twoHops, config.SwapConfig{MaxRouteHops: 1}, true},
+		{"allowed AMM", direct, config.SwapConfig{AllowAmms: []string{"raydium"}}, false},
+		{"AMM not allowed", split, config.SwapConfig{AllowAmms: []string{"Raydium"}}, true},
+		{"denied AMM", twoHops, config.SwapConfig{DenyAmms: []string{"Meteora"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := guardQuote(&tt.quote, tt.swap)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/low4ey/sniper/internal/config"
+	"github.com/low4ey/sniper/package/models"
+	"github.com/low4ey/sniper/package/tracker/db"
+)
+
+// ---------- Paper Trading ----------
+
+// SimulatedTx is returned in place of a transaction signature when
+// RugCheckConfig.SimulationMode is on and nothing was sent.
+const SimulatedTx = "simulated"
+
+const (
+	lamportsPerSOL = 1_000_000_000
+	// baseFeeLamports is the network fee charged per transaction signature.
+	baseFeeLamports = 5000
+)
+
+// paperFeeLamports is the worst case fee of a swap: the signature fee plus the
+// maximum priority fee the swap would have been allowed to pay.
+func paperFeeLamports(prioFeeMaxLamports int) float64 {
+	return float64(baseFeeLamports + prioFeeMaxLamports)
+}
+
+// paperBuy records the fill the quote would have produced as a paper holding.
+func (c *Client) paperBuy(ctx context.Context, tokenMint string, quote *models.QuoteResponse) error {
+	cfg := config.Get()
+	decimals, err := c.tokenDecimals(ctx, tokenMint)
+	if err != nil {
+		return err
+	}
+	solPrice, err := c.solPrice.PriceUSD(ctx, cfg.LiquidityPool.WsolPcMint)
+	if err != nil {
+		return err
+	}
+
+	balance := float64(quote.OutAmount) / math.Pow10(int(decimals))
+	if balance <= 0 {
+		return fmt.Errorf("quote for %s has no output", tokenMint)
+	}
+	solPaid := float64(quote.InAmount) / lamportsPerSOL
+	solFeePaid := paperFeeLamports(cfg.Swap.PrioFeeMaxLamports)
+	solPaidUSDC := solPaid * solPrice
+
+	tokenName := "N/A"
+	tokens, err := db.SelectTokenByMint(tokenMint)
+	if err == nil && len(tokens) > 0 {
+		tokenName = tokens[0].Name
+	}
+	program := ""
+	if len(quote.RoutePlan) > 0 {
+		program = quote.RoutePlan[0].SwapInfo.Label
+	}
+
+	holding := models.HoldingRecord{
+		Time:             int(time.Now().Unix()),
+		Token:            tokenMint,
+		TokenName:        tokenName,
+		Balance:          balance,
+		SolPaid:          solPaid,
+		SolFeePaid:       solFeePaid,
+		SolPaidUSDC:      solPaidUSDC,
+		SolFeePaidUSDC:   solFeePaid / lamportsPerSOL * solPrice,
+		PerTokenPaidUSDC: solPaidUSDC / balance,
+		Slot:             quote.ContextSlot,
+		Program:          program,
+	}
+	if err := db.InsertPaperHolding(holding); err != nil {
+		return err
+	}
+	log.Printf("📝 Paper buy of %s: %.6f tokens for %.6f SOL", tokenMint, balance, solPaid)
+	return nil
+}
+
+// paperSell closes the paper holdings of tokenMint at the SOL the quote would
+// have returned, net of fees.
+func (c *Client) paperSell(tokenMint string, quote *models.QuoteResponse) (*models.CreateSellTransactionResponse, error) {
+	cfg := config.Get()
+	holdings, err := db.SelectOpenPaperHoldingsByMint(tokenMint)
+	if err != nil {
+		return &models.CreateSellTransactionResponse{Success: false, Msg: err.Error()}, err
+	}
+	if len(holdings) == 0 {
+		err := fmt.Errorf("no open paper holding for %s", tokenMint)
+		return &models.CreateSellTransactionResponse{Success: false, Msg: err.Error()}, err
+	}
+	solReceived := (float64(quote.OutAmount) - paperFeeLamports(cfg.Sell.PrioFeeMaxLamports)) / lamportsPerSOL
+	if err := db.ClosePaperHolding(tokenMint, int(time.Now().Unix()), solReceived); err != nil {
+		return &models.CreateSellTransactionResponse{Success: false, Msg: err.Error()}, err
+	}
+
+	solSpent := 0.0
+	for _, holding := range holdings {
+		solSpent += holding.SolPaid + holding.SolFeePaid/lamportsPerSOL
+	}
+	log.Printf("📝 Paper sell of %s: %.6f SOL received for %.6f SOL spent (%+.2f%%)",
+		tokenMint, solReceived, solSpent, (solReceived-solSpent)/solSpent*100)
+	return &models.CreateSellTransactionResponse{Success: true, Tx: SimulatedTx}, nil
+}
+
+// paperBalance returns the raw amount of tokenMint held in open paper
+// holdings.
+func (c *Client) paperBalance(ctx context.Context, tokenMint string) (uint64, error) {
+	holdings, err := db.SelectOpenPaperHoldingsByMint(tokenMint)
+	if err != nil || len(holdings) == 0 {
+		return 0, err
+	}
+	decimals, err := c.tokenDecimals(ctx, tokenMint)
+	if err != nil {
+		return 0, err
+	}
+	balance := 0.0
+	for _, holding := range holdings {
+		balance += holding.Balance
+	}
+	return uint64(math.Round(balance * math.Pow10(int(decimals)))), nil
+}
+
+func (c *Client) tokenDecimals(ctx context.Context, tokenMint string) (uint8, error) {
+	mint, err := solana.PublicKeyFromBase58(tokenMint)
+	if err != nil {
+		return 0, err
+	}
+	supply, err := c.rpcClient.GetTokenSupply(ctx, mint, rpc.CommitmentConfirmed)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch decimals of %s: %v", tokenMint, err)
+	}
+	return supply.Value.Decimals, nil
+}
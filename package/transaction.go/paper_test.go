@@ -0,0 +1,144 @@
+package transactions
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/low4ey/sniper/internal/config"
+	envinit "github.com/low4ey/sniper/internal/init"
+	"github.com/low4ey/sniper/package/tracker/db"
+)
+
+// newQuoteStandIn quotes 1 SOL for 2000 tokens and 2000 tokens for 1.5 SOL.
+func newQuoteStandIn(t *testing.T) string {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		outAmount := "2000000000" // 2000 tokens of 6 decimals
+		if query.Get("inputMint") != solana.WrappedSol.String() {
+			outAmount = "1500000000" // 1.5 SOL
+		}
+		fmt.Fprintf(w, `{
+			"inputMint": %q, "inAmount": %q, "outputMint": %q, "outAmount": %q, "otherAmountThreshold": %q,
+			"swapMode": "ExactIn", "slippageBps": 200, "priceImpactPct": "0.01", "contextSlot": 42,
+			"routePlan": [{"swapInfo": {"label": "Raydium"}, "percent": 100}]
+		}`, query.Get("inputMint"), query.Get("amount"), query.Get("outputMint"), outAmount, outAmount)
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func newSolPriceStandIn(t *testing.T) string {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Query().Get("ids")
+		fmt.Fprintf(w, `{"data": {%q: {"id": %q, "price": "150"}}}`, id, id)
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestPaperTrade(t *testing.T) {
+	cfg := config.ConfigVal
+	cfg.RugCheck.SimulationMode = true
+	cfg.Swap.Amount = "1000000000" // 1 SOL
+	cfg.Swap.PrioFeeMaxLamports = 1_000_000
+	cfg.Sell.PrioFeeMaxLamports = 1_000_000
+	config.Set(&cfg)
+	t.Cleanup(func() { config.Set(nil) })
+
+	dbPath := filepath.Join(t.TempDir(), "holdings.db")
+	if err := db.Open(dbPath); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	rpcURL := newRPCStandIn(t, func(method string, params json.RawMessage) interface{} {
+		if method != "getTokenSupply" {
+			t.Errorf("unexpected call %s", method)
+			return nil
+		}
+		return map[string]interface{}{
+			"context": map[string]int{"slot": 1},
+			"value":   map[string]interface{}{"amount": "1000000000000", "decimals": 6, "uiAmountString": "1000000"},
+		}
+	})
+	c, err := NewClient(&envinit.EnvConfig{
+		HeliusHTTPSURI:   rpcURL,
+		JupHTTPSQuoteURI: newQuoteStandIn(t),
+		JupHTTPSPriceURI: newSolPriceStandIn(t),
+	}, http.DefaultClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	sol, token := solana.WrappedSol.String(), solana.NewWallet().PublicKey().String()
+
+	tx, err := c.CreateSwapTransaction(ctx, sol, token)
+	if err != nil || tx != SimulatedTx {
+		t.Fatalf("got (%q, %v), want a simulated buy", tx, err)
+	}
+	holdings, err := db.SelectOpenPaperHoldingsByMint(token)
+	if err != nil || len(holdings) != 1 {
+		t.Fatalf("got %d paper holdings (%v), want 1", len(holdings), err)
+	}
+	const feeLamports = baseFeeLamports + 1_000_000
+	holding := holdings[0]
+	for _, check := range []struct {
+		field     string
+		got, want float64
+	}{
+		{"Balance", holding.Balance, 2000},
+		{"SolPaid", holding.SolPaid, 1},                 // SOL
+		{"SolFeePaid", holding.SolFeePaid, feeLamports}, // lamports
+		{"SolPaidUSDC", holding.SolPaidUSDC, 150},
+		{"SolFeePaidUSDC", holding.SolFeePaidUSDC, feeLamports / 1e9 * 150},
+		{"PerTokenPaidUSDC", holding.PerTokenPaidUSDC, 150.0 / 2000},
+	} {
+		if math.Abs(check.got-check.want) > 1e-9 {
+			t.Errorf("%s = %v, want %v", check.field, check.got, check.want)
+		}
+	}
+	if holding.Slot != 42 || holding.Program != "Raydium" {
+		t.Errorf("slot %d and program %q, want 42 and Raydium", holding.Slot, holding.Program)
+	}
+
+	balance, err := c.TokenBalance(ctx, token)
+	if err != nil || balance != 2_000_000_000 {
+		t.Fatalf("got paper balance (%d, %v), want 2000000000", balance, err)
+	}
+	resp, err := c.CreateSellTransaction(ctx, sol, token, fmt.Sprint(balance))
+	if err != nil || !resp.Success || resp.Tx != SimulatedTx {
+		t.Fatalf("got (%+v, %v), want a simulated sell", resp, err)
+	}
+	if open, _ := db.SelectOpenPaperHoldingsByMint(token); len(open) != 0 {
+		t.Fatalf("%d paper holdings still open after the sell", len(open))
+	}
+
+	conn, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	var soldTime sql.NullInt64
+	var solReceived float64
+	if err := conn.QueryRow("SELECT soldTime, solReceived FROM paper_holdings WHERE token = ?", token).Scan(&soldTime, &solReceived); err != nil {
+		t.Fatal(err)
+	}
+	wantReceived := (1.5e9 - feeLamports) / 1e9
+	if !soldTime.Valid || math.Abs(solReceived-wantReceived) > 1e-9 {
+		t.Fatalf("sold at %v for %v SOL, want a sell time and %v SOL", soldTime, solReceived, wantReceived)
+	}
+	pnl := solReceived - (holding.SolPaid + holding.SolFeePaid/lamportsPerSOL)
+	if want := 1.5 - 1 - 2*feeLamports/1e9; math.Abs(pnl-want) > 1e-9 {
+		t.Fatalf("realized PnL %v SOL, want %v", pnl, want)
+	}
+}
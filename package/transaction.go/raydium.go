@@ -0,0 +1,238 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	computebudget "github.com/gagliardetto/solana-go/programs/compute-budget"
+	"github.com/gagliardetto/solana-go/programs/system"
+	"github.com/gagliardetto/solana-go/programs/token"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/low4ey/sniper/internal/config"
+	"github.com/low4ey/sniper/package/models"
+	"github.com/low4ey/sniper/package/raydium"
+)
+
+// raydiumLabel names direct Raydium swaps in quotes and holdings, matching
+// the label Jupiter uses for the same AMM.
+const raydiumLabel = "Raydium"
+
+// raydiumPoolState is what a direct swap needs to know about a pool beyond
+// its keys.
+type raydiumPoolState struct {
+	market       *raydium.MarketKeys
+	baseReserve  uint64
+	quoteReserve uint64
+	slot         uint64
+}
+
+// ---------- Function: CreateRaydiumSwapTransaction ----------
+
+// CreateRaydiumSwapTransaction buys tokenMint with SwapConfig.Amount lamports
+// by swapping directly against the Raydium AMM v4 pool, without asking
+// Jupiter for a route. The output is priced locally from the pool reserves.
+func (c *Client) CreateRaydiumSwapTransaction(ctx context.Context, pool *raydium.PoolKeys, solMint, tokenMint string) (string, error) {
+	cfg := config.Get()
+	simulate := cfg.RugCheck.SimulationMode
+	var wallet solana.PrivateKey
+	if !simulate {
+		var err error
+		wallet, err = c.requireWallet()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	amountIn, err := strconv.ParseUint(cfg.Swap.Amount, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid swap amount %q: %v", cfg.Swap.Amount, err)
+	}
+	slippageBps, err := strconv.Atoi(cfg.Swap.SlippageBps)
+	if err != nil {
+		return "", fmt.Errorf("invalid slippage %q: %v", cfg.Swap.SlippageBps, err)
+	}
+
+	// --- Price the Swap from the Pool Reserves ---
+	state, err := c.fetchRaydiumPoolState(ctx, pool)
+	if err != nil {
+		return "", err
+	}
+	quote, err := raydiumQuote(pool, state, solMint, tokenMint, amountIn, slippageBps)
+	if err != nil {
+		return "", err
+	}
+	log.Printf("✅ Raydium quote computed: %d out, %.2f%% price impact.", quote.OutAmount, quote.PriceImpactPct*100)
+	if err := guardQuote(quote, cfg.Swap); err != nil {
+		log.Printf("🚫 Raydium quote rejected: %v", err)
+		return "", err
+	}
+	if simulate {
+		if err := c.paperBuy(ctx, tokenMint, quote); err != nil {
+			return "", fmt.Errorf("failed to record paper buy: %v", err)
+		}
+		return SimulatedTx, nil
+	}
+
+	// --- Build, Sign, Simulate and Send Transaction ---
+	instructions, err := raydiumBuyInstructions(pool, state.market, wallet.PublicKey(), quote, cfg.Swap)
+	if err != nil {
+		return "", err
+	}
+	blockhash, err := c.rpcClient.GetLatestBlockhash(ctx, rpc.CommitmentConfirmed)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch blockhash: %w", err)
+	}
+	tx, err := solana.NewTransaction(instructions, blockhash.Value.Blockhash, solana.TransactionPayer(wallet.PublicKey()))
+	if err != nil {
+		return "", fmt.Errorf("failed to build transaction: %v", err)
+	}
+	if err := signTransaction(tx, wallet); err != nil {
+		return "", err
+	}
+	if err := simulateTransaction(ctx, c.rpcClient, tx, tokenMint, "buy"); err != nil {
+		return "", err
+	}
+
+	txid, err := sendTransaction(ctx, c.rpcClient, tx)
+	if err != nil {
+		return "", fmt.Errorf("failed to send transaction: %w", err)
+	}
+	log.Printf("✅ Raw transaction id received: %s", txid)
+
+	confirmCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	if err := confirmTransaction(confirmCtx, c.rpcClient, txid, blockhash.Value.LastValidBlockHeight); err != nil {
+		return "", fmt.Errorf("transaction confirmation failed: %w", err)
+	}
+	log.Printf("Transaction confirmed.")
+	return txid.String(), nil
+}
+
+// fetchRaydiumPoolState reads the market keys and vault balances of pool in a
+// single RPC call.
+func (c *Client) fetchRaydiumPoolState(ctx context.Context, pool *raydium.PoolKeys) (*raydiumPoolState, error) {
+	accounts, err := c.rpcClient.GetMultipleAccountsWithOpts(ctx,
+		[]solana.PublicKey{pool.MarketID, pool.BaseVault, pool.QuoteVault},
+		&rpc.GetMultipleAccountsOpts{Commitment: rpc.CommitmentProcessed},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pool %s: %w", pool.ID, err)
+	}
+	for i, account := range accounts.Value {
+		if account == nil {
+			return nil, fmt.Errorf("account %d of pool %s not found", i, pool.ID)
+		}
+	}
+
+	market, err := raydium.DecodeMarket(pool, accounts.Value[0].Data.GetBinary())
+	if err != nil {
+		return nil, err
+	}
+	baseReserve, err := raydium.TokenAccountAmount(accounts.Value[1].Data.GetBinary())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read base vault of pool %s: %v", pool.ID, err)
+	}
+	quoteReserve, err := raydium.TokenAccountAmount(accounts.Value[2].Data.GetBinary())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read quote vault of pool %s: %v", pool.ID, err)
+	}
+	return &raydiumPoolState{
+		market:       market,
+		baseReserve:  baseReserve,
+		quoteReserve: quoteReserve,
+		slot:         accounts.Context.Slot,
+	}, nil
+}
+
+// raydiumQuote prices swapping amountIn of inputMint for outputMint against
+// the pool reserves, in the shape of a Jupiter quote so the same guards and
+// paper trading apply.
+func raydiumQuote(pool *raydium.PoolKeys, state *raydiumPoolState, inputMint, outputMint string, amountIn uint64, slippageBps int) (*models.QuoteResponse, error) {
+	var reserveIn, reserveOut uint64
+	switch {
+	case pool.QuoteMint.String() == inputMint && pool.BaseMint.String() == outputMint:
+		reserveIn, reserveOut = state.quoteReserve, state.baseReserve
+	case pool.BaseMint.String() == inputMint && pool.QuoteMint.String() == outputMint:
+		reserveIn, reserveOut = state.baseReserve, state.quoteReserve
+	default:
+		return nil, fmt.Errorf("pool %s does not trade %s for %s", pool.ID, inputMint, outputMint)
+	}
+
+	amountOut := raydium.AmountOut(amountIn, reserveIn, reserveOut)
+	if amountOut == 0 {
+		return nil, fmt.Errorf("pool %s returns nothing for %d (reserves %d/%d)", pool.ID, amountIn, reserveIn, reserveOut)
+	}
+	minAmountOut := raydium.MinAmountOut(amountOut, slippageBps)
+	return &models.QuoteResponse{
+		InputMint:            inputMint,
+		InAmount:             amountIn,
+		OutputMint:           outputMint,
+		OutAmount:            amountOut,
+		OtherAmountThreshold: minAmountOut,
+		SwapMode:             "ExactIn",
+		SlippageBps:          slippageBps,
+		PriceImpactPct:       raydium.PriceImpact(amountIn, reserveIn),
+		RoutePlan: []models.RoutePlan{{
+			SwapInfo: models.SwapInfo{
+				AmmKey:     pool.ID.String(),
+				Label:      raydiumLabel,
+				InputMint:  inputMint,
+				OutputMint: outputMint,
+				InAmount:   amountIn,
+				OutAmount:  amountOut,
+			},
+			Percent: 100,
+		}},
+		ContextSlot: int(state.slot),
+	}, nil
+}
+
+// raydiumBuyInstructions wraps quote.InAmount lamports into a WSOL account,
+// swaps them into the token's associated account (created if missing) and
+// closes the WSOL account again, behind compute budget instructions spending
+// at most swap.PrioFeeMaxLamports on priority fees.
+func raydiumBuyInstructions(pool *raydium.PoolKeys, market *raydium.MarketKeys, owner solana.PublicKey, quote *models.QuoteResponse, swap config.SwapConfig) ([]solana.Instruction, error) {
+	tokenMint, err := solana.PublicKeyFromBase58(quote.OutputMint)
+	if err != nil {
+		return nil, err
+	}
+	wsolAccount, _, err := solana.FindAssociatedTokenAddress(owner, solana.WrappedSol)
+	if err != nil {
+		return nil, err
+	}
+	tokenAccount, _, err := solana.FindAssociatedTokenAddress(owner, tokenMint)
+	if err != nil {
+		return nil, err
+	}
+
+	units := uint32(swap.ComputeUnitLimit)
+	microLamports := uint64(swap.PrioFeeMaxLamports) * 1_000_000 / uint64(units)
+	return []solana.Instruction{
+		computebudget.NewSetComputeUnitLimitInstruction(units).Build(),
+		computebudget.NewSetComputeUnitPriceInstruction(microLamports).Build(),
+		createATAIdempotent(owner, owner, solana.WrappedSol),
+		system.NewTransferInstruction(quote.InAmount, owner, wsolAccount).Build(),
+		token.NewSyncNativeInstruction(wsolAccount).Build(),
+		createATAIdempotent(owner, owner, tokenMint),
+		raydium.NewSwapBaseInInstruction(pool, market, wsolAccount, tokenAccount, owner, quote.InAmount, quote.OtherAmountThreshold),
+		token.NewCloseAccountInstruction(wsolAccount, owner, owner, nil).Build(),
+	}, nil
+}
+
+// createATAIdempotent creates the associated token account of wallet for
+// mint, doing nothing if it already exists.
+func createATAIdempotent(payer, wallet, mint solana.PublicKey) solana.Instruction {
+	ata, _, _ := solana.FindAssociatedTokenAddress(wallet, mint)
+	return solana.NewInstruction(solana.SPLAssociatedTokenAccountProgramID, solana.AccountMetaSlice{
+		solana.Meta(payer).WRITE().SIGNER(),
+		solana.Meta(ata).WRITE(),
+		solana.Meta(wallet),
+		solana.Meta(mint),
+		solana.Meta(solana.SystemProgramID),
+		solana.Meta(solana.TokenProgramID),
+	}, []byte{1}) // 1 = CreateIdempotent
+}
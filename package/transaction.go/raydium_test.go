@@ -0,0 +1,311 @@
+package transactions
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/serum"
+	"github.com/low4ey/sniper/internal/config"
+	envinit "github.com/low4ey/sniper/internal/init"
+	"github.com/low4ey/sniper/package/models"
+	"github.com/low4ey/sniper/package/raydium"
+	"github.com/low4ey/sniper/package/tracker/db"
+	"github.com/mr-tron/base58"
+)
+
+func TestRaydiumQuote(t *testing.T) {
+	token := solana.NewWallet().PublicKey()
+	pool := &raydium.PoolKeys{ID: solana.NewWallet().PublicKey(), BaseMint: token, QuoteMint: solana.WrappedSol}
+	state := &raydiumPoolState{baseReserve: 1_000_000_000_000, quoteReserve: 100_000_000_000, slot: 42}
+
+	quote, err := raydiumQuote(pool, state, solana.WrappedSol.String(), token.String(), 1_000_000_000, 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if quote.OutAmount != 9_876_482_091 || quote.OtherAmountThreshold != 9_678_952_449 || quote.ContextSlot != 42 {
+		t.Fatalf("unexpected quote %+v", quote)
+	}
+	if len(quote.RoutePlan) != 1 || quote.RoutePlan[0].SwapInfo.Label != raydiumLabel {
+		t.Fatalf("unexpected route %+v", quote.RoutePlan)
+	}
+	if err := guardQuote(quote, config.ConfigVal.Swap); err != nil {
+		t.Fatalf("quote within limits rejected: %v", err)
+	}
+
+	if _, err := raydiumQuote(pool, state, solana.WrappedSol.String(), solana.NewWallet().PublicKey().String(), 1_000_000_000, 200); err == nil {
+		t.Fatal("expected an error for a mint the pool does not trade")
+	}
+}
+
+func TestRaydiumBuyInstructions(t *testing.T) {
+	token := solana.NewWallet().PublicKey()
+	owner := solana.NewWallet().PublicKey()
+	pool := &raydium.PoolKeys{BaseMint: token, QuoteMint: solana.WrappedSol}
+	state := &raydiumPoolState{market: &raydium.MarketKeys{}, baseReserve: 1_000_000, quoteReserve: 1_000_000}
+	quote, err := raydiumQuote(pool, state, solana.WrappedSol.String(), token.String(), 1000, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	instructions, err := raydiumBuyInstructions(pool, state.market, owner, quote, config.ConfigVal.Swap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	programs := []solana.PublicKey{
+		solana.ComputeBudget, solana.ComputeBudget,
+		solana.SPLAssociatedTokenAccountProgramID, solana.SystemProgramID, solana.TokenProgramID,
+		solana.SPLAssociatedTokenAccountProgramID, raydium.AmmV4ProgramID, solana.TokenProgramID,
+	}
+	if len(instructions) != len(programs) {
+		t.Fatalf("got %d instructions, want %d", len(instructions), len(programs))
+	}
+	for i, inst := range instructions {
+		if inst.ProgramID() != programs[i] {
+			t.Fatalf("instruction %d targets %s, want %s", i, inst.ProgramID(), programs[i])
+		}
+	}
+	if _, err := solana.NewTransaction(instructions, solana.Hash{}, solana.TransactionPayer(owner)); err != nil {
+		t.Fatalf("instructions do not form a transaction: %v", err)
+	}
+}
+
+func TestFindPoolCreation(t *testing.T) {
+	accounts := make([]string, 21)
+	for i := range accounts {
+		accounts[i] = solana.NewWallet().PublicKey().String()
+	}
+	data := base58.Encode(append([]byte{1, 255}, make([]byte, 24)...))
+	programID := raydium.AmmV4ProgramID.String()
+	programs := map[string]raydium.PoolType{programID: raydium.AmmV4}
+
+	instructions := []models.Instructions{
+		{ProgramId: solana.ComputeBudget.String(), Data: data, Accounts: accounts},
+		{ProgramId: programID, Data: base58.Encode([]byte{9}), Accounts: accounts},
+		{ProgramId: programID, Data: data, Accounts: accounts},
+	}
+	pool, err := findPoolCreation(instructions, programs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pool.Type != raydium.AmmV4 || pool.ID.String() != accounts[4] {
+		t.Fatalf("pool %s, want %s", pool.ID, accounts[4])
+	}
+
+	if _, err := findPoolCreation(instructions[:2], programs); err == nil {
+		t.Fatal("expected an error without an initialize2 instruction")
+	}
+}
+
+// newMarketFixture encodes an OpenBook market account for pool and returns it
+// with the vault signer its nonce derives.
+func newMarketFixture(t *testing.T, pool *raydium.PoolKeys) ([]byte, *raydium.MarketKeys) {
+	t.Helper()
+	// Not every nonce yields an off-curve address; use the first that does.
+	var nonce uint64
+	var vaultSigner solana.PublicKey
+	for ; ; nonce++ {
+		seed := make([]byte, 8)
+		binary.LittleEndian.PutUint64(seed, nonce)
+		var err error
+		if vaultSigner, err = solana.CreateProgramAddress([][]byte{pool.MarketID.Bytes(), seed}, pool.MarketProgramID); err == nil {
+			break
+		}
+	}
+	keys := &raydium.MarketKeys{
+		Bids:        solana.NewWallet().PublicKey(),
+		Asks:        solana.NewWallet().PublicKey(),
+		EventQueue:  solana.NewWallet().PublicKey(),
+		BaseVault:   solana.NewWallet().PublicKey(),
+		QuoteVault:  solana.NewWallet().PublicKey(),
+		VaultSigner: vaultSigner,
+	}
+	market := serum.MarketV2{
+		OwnAddress:       pool.MarketID,
+		VaultSignerNonce: bin.Uint64(nonce),
+		BaseMint:         pool.BaseMint,
+		QuoteMint:        pool.QuoteMint,
+		BaseVault:        keys.BaseVault,
+		QuoteVault:       keys.QuoteVault,
+		EventQueue:       keys.EventQueue,
+		Bids:             keys.Bids,
+		Asks:             keys.Asks,
+	}
+	var buf bytes.Buffer
+	if err := bin.NewBinEncoder(&buf).Encode(&market); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes(), keys
+}
+
+// tokenAccountData lays out an SPL token account of mint holding amount.
+func tokenAccountData(mint solana.PublicKey, amount uint64) []byte {
+	data := make([]byte, 165)
+	copy(data, mint.Bytes())
+	binary.LittleEndian.PutUint64(data[64:72], amount)
+	return data
+}
+
+func TestCreateRaydiumSwapTransaction(t *testing.T) {
+	cfg := config.ConfigVal
+	cfg.RugCheck.SimulationMode = false
+	config.Set(&cfg)
+	t.Cleanup(func() { config.Set(nil) })
+	if err := db.Open(filepath.Join(t.TempDir(), "holdings.db")); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	token := solana.NewWallet().PublicKey()
+	pool := &raydium.PoolKeys{
+		ID:              solana.NewWallet().PublicKey(),
+		Authority:       solana.MustPublicKeyFromBase58("5Q544fKrFoe6tsEbD7S8EmxGTJYAKtTVhAW5Q5pge4j1"),
+		OpenOrders:      solana.NewWallet().PublicKey(),
+		TargetOrders:    solana.NewWallet().PublicKey(),
+		BaseMint:        token,
+		QuoteMint:       solana.WrappedSol,
+		BaseVault:       solana.NewWallet().PublicKey(),
+		QuoteVault:      solana.NewWallet().PublicKey(),
+		MarketProgramID: solana.MustPublicKeyFromBase58("srmqPvymJeFKQ4zGQed1GFppgkRHL9kaELCbyksJtPX"),
+		MarketID:        solana.NewWallet().PublicKey(),
+	}
+	marketData, market := newMarketFixture(t, pool)
+	const baseReserve, quoteReserve = 1_000_000_000_000, 100_000_000_000
+	accounts := map[solana.PublicKey][]byte{
+		pool.MarketID:   marketData,
+		pool.BaseVault:  tokenAccountData(token, baseReserve),
+		pool.QuoteVault: tokenAccountData(solana.WrappedSol, quoteReserve),
+	}
+	blockhash := solana.MustHashFromBase58("4uQeVj5tqViQh7yWWGStvkEG1Zmhx6uasJtWCJziofM")
+
+	var simulated, sent *solana.Transaction
+	decodeTx := func(params json.RawMessage) *solana.Transaction {
+		var args []json.RawMessage
+		var encoded string
+		if json.Unmarshal(params, &args) != nil || len(args) == 0 || json.Unmarshal(args[0], &encoded) != nil {
+			t.Errorf("malformed params %s", params)
+			return nil
+		}
+		tx, err := solana.TransactionFromBase64(encoded)
+		if err != nil {
+			t.Errorf("undecodable transaction: %v", err)
+		}
+		return tx
+	}
+	rpcURL := newRPCStandIn(t, func(method string, params json.RawMessage) interface{} {
+		switch method {
+		case "getMultipleAccounts":
+			var args []json.RawMessage
+			var keys []solana.PublicKey
+			json.Unmarshal(params, &args)
+			if len(args) == 0 || json.Unmarshal(args[0], &keys) != nil {
+				t.Errorf("malformed params %s", params)
+				return nil
+			}
+			values := make([]interface{}, len(keys))
+			for i, key := range keys {
+				data, ok := accounts[key]
+				if !ok {
+					continue
+				}
+				values[i] = map[string]interface{}{
+					"data":       []string{base64.StdEncoding.EncodeToString(data), "base64"},
+					"executable": false,
+					"lamports":   2039280,
+					"owner":      solana.TokenProgramID.String(),
+					"rentEpoch":  0,
+				}
+			}
+			return map[string]interface{}{"context": map[string]int{"slot": 42}, "value": values}
+		case "getLatestBlockhash":
+			return map[string]interface{}{
+				"context": map[string]int{"slot": 42},
+				"value":   map[string]interface{}{"blockhash": blockhash.String(), "lastValidBlockHeight": 100},
+			}
+		case "simulateTransaction":
+			simulated = decodeTx(params)
+			return map[string]interface{}{
+				"context": map[string]int{"slot": 42},
+				"value":   map[string]interface{}{"err": nil, "logs": []string{}, "unitsConsumed": 45000},
+			}
+		case "sendTransaction":
+			sent = decodeTx(params)
+			if sent == nil || len(sent.Signatures) == 0 {
+				return nil
+			}
+			return sent.Signatures[0].String()
+		case "getSignatureStatuses":
+			status := map[string]interface{}{"slot": 43, "confirmations": nil, "err": nil, "confirmationStatus": "confirmed"}
+			return map[string]interface{}{"context": map[string]int{"slot": 43}, "value": []interface{}{status}}
+		}
+		t.Errorf("unexpected call %s", method)
+		return nil
+	})
+
+	wallet := solana.NewWallet().PrivateKey
+	c, err := NewClient(&envinit.EnvConfig{PrivKeyWallet: wallet.String(), HeliusHTTPSURI: rpcURL}, http.DefaultClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txid, err := c.CreateRaydiumSwapTransaction(context.Background(), pool, solana.WrappedSol.String(), token.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if simulated == nil || sent == nil {
+		t.Fatal("transaction was not simulated and sent")
+	}
+	if txid != sent.Signatures[0].String() || simulated.Signatures[0] != sent.Signatures[0] {
+		t.Fatalf("got txid %s, want the signature of the simulated and sent transaction %s", txid, sent.Signatures[0])
+	}
+	if err := sent.VerifySignatures(); err != nil || !sent.Message.AccountKeys[0].Equals(wallet.PublicKey()) {
+		t.Fatalf("transaction not signed by the wallet as fee payer: %v", err)
+	}
+	if sent.Message.RecentBlockhash != blockhash {
+		t.Fatalf("blockhash %s, want %s", sent.Message.RecentBlockhash, blockhash)
+	}
+
+	var swap *solana.CompiledInstruction
+	for i, inst := range sent.Message.Instructions {
+		if sent.Message.AccountKeys[inst.ProgramIDIndex].Equals(raydium.AmmV4ProgramID) {
+			swap = &sent.Message.Instructions[i]
+		}
+	}
+	if swap == nil {
+		t.Fatal("no Raydium AMM v4 instruction in the transaction")
+	}
+	wsolAccount, _, _ := solana.FindAssociatedTokenAddress(wallet.PublicKey(), solana.WrappedSol)
+	tokenAccount, _, _ := solana.FindAssociatedTokenAddress(wallet.PublicKey(), token)
+	wantAccounts := []solana.PublicKey{
+		solana.TokenProgramID, pool.ID, pool.Authority, pool.OpenOrders, pool.TargetOrders,
+		pool.BaseVault, pool.QuoteVault, pool.MarketProgramID, pool.MarketID,
+		market.Bids, market.Asks, market.EventQueue, market.BaseVault, market.QuoteVault, market.VaultSigner,
+		wsolAccount, tokenAccount, wallet.PublicKey(),
+	}
+	if len(swap.Accounts) != len(wantAccounts) {
+		t.Fatalf("swap has %d accounts, want %d", len(swap.Accounts), len(wantAccounts))
+	}
+	for i, index := range swap.Accounts {
+		if got := sent.Message.AccountKeys[index]; !got.Equals(wantAccounts[i]) {
+			t.Errorf("swap account %d is %s, want %s", i, got, wantAccounts[i])
+		}
+	}
+
+	const amountIn = 10_000_000 // SwapConfig.Amount
+	minAmountOut := raydium.MinAmountOut(raydium.AmountOut(amountIn, quoteReserve, baseReserve), 200)
+	if len(swap.Data) != 17 || binary.LittleEndian.Uint64(swap.Data[1:9]) != amountIn ||
+		binary.LittleEndian.Uint64(swap.Data[9:17]) != minAmountOut {
+		t.Fatalf("swap data %x, want %d in and at least %d out", []byte(swap.Data), amountIn, minAmountOut)
+	}
+
+	records, err := db.SelectSimulationsBySignature(txid)
+	if err != nil || len(records) != 1 || records[0].UnitsConsumed != 45000 {
+		t.Fatalf("got simulations %+v (%v), want the one run before sending", records, err)
+	}
+}
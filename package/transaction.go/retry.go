@@ -0,0 +1,60 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/low4ey/sniper/internal/config"
+	"github.com/low4ey/sniper/package/jupiter"
+)
+
+// ---------- Quote Retries ----------
+
+// quoteRetryDelay decides whether a quote that failed with err on the given
+// attempt (starting at 1) is retried, and after how long. Only
+// TOKEN_NOT_TRADABLE is retried here, waiting
+// swap.token_not_tradable_400_error_delay up to
+// swap.token_not_tradable_400_error_retries attempts, since fresh pools take a
+// moment to become tradable. Rate limits, server errors and network failures
+// are already retried by the shared HTTP client; every other error is final.
+func quoteRetryDelay(err error, attempt int, cfg *config.Config) (time.Duration, bool) {
+	var apiErr *jupiter.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != jupiter.TokenNotTradable {
+		return 0, false
+	}
+	return time.Duration(cfg.Swap.TokenNotTradable400ErrorDelay) * time.Millisecond, attempt < cfg.Swap.TokenNotTradable400ErrorRetries
+}
+
+// quoteWithRetry requests a quote, retrying failures as quoteRetryDelay
+// allows.
+func (c *Client) quoteWithRetry(ctx context.Context, inputMint, outputMint, amount, slippageBps string) (*jupiter.Quote, error) {
+	cfg := config.Get()
+	for attempt := 1; ; attempt++ {
+		quote, err := c.quotes.Quote(ctx, inputMint, outputMint, amount, slippageBps)
+		if err == nil {
+			return quote, nil
+		}
+		delay, retry := quoteRetryDelay(err, attempt, cfg)
+		if !retry {
+			return nil, err
+		}
+		log.Printf("Quote attempt %d failed, retrying in %v: %v", attempt, delay, err)
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// sleep waits for d, returning ctx.Err() early if ctx is done first.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
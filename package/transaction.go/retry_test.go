@@ -0,0 +1,59 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/low4ey/sniper/internal/config"
+	envinit "github.com/low4ey/sniper/internal/init"
+	"github.com/low4ey/sniper/package/jupiter"
+)
+
+func TestQuoteRetryDelay(t *testing.T) {
+	cfg := config.ConfigVal
+	cfg.Swap.TokenNotTradable400ErrorRetries = 5
+	cfg.Swap.TokenNotTradable400ErrorDelay = 2000
+
+	tests := []struct {
+		name      string
+		err       error
+		attempt   int
+		wantDelay time.Duration
+		wantRetry bool
+	}{
+		{"not tradable", &jupiter.APIError{Code: jupiter.TokenNotTradable}, 1, 2 * time.Second, true},
+		{"not tradable, retries used up", &jupiter.APIError{Code: jupiter.TokenNotTradable}, 5, 2 * time.Second, false},
+		{"no routes", &jupiter.APIError{Code: jupiter.NoRoutesFound}, 1, 0, false},
+		{"could not find any route", &jupiter.APIError{Code: jupiter.CouldNotFindAnyRoute}, 1, 0, false},
+		// Retried by the shared HTTP client, not again per quote.
+		{"rate limited", &jupiter.APIError{Code: jupiter.RateLimited, RetryAfter: 3 * time.Second}, 1, 0, false},
+		{"server error", &jupiter.APIError{Code: jupiter.ServerError}, 1, 0, false},
+		{"network error", errors.New("connection reset"), 1, 0, false},
+		{"cancelled", context.Canceled, 1, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, retry := quoteRetryDelay(tt.err, tt.attempt, &cfg)
+			if retry != tt.wantRetry || (retry && delay != tt.wantDelay) {
+				t.Fatalf("got (%v, %v), want (%v, %v)", delay, retry, tt.wantDelay, tt.wantRetry)
+			}
+		})
+	}
+}
+
+func TestFetchTransactionDetailsCancelled(t *testing.T) {
+	c := &Client{env: &envinit.EnvConfig{}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	_, err := c.FetchTransactionDetails(ctx, "signature")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want %v", err, context.Canceled)
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Fatalf("returned after %v, want it to stop at once", elapsed)
+	}
+}
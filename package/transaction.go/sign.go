@@ -0,0 +1,118 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/low4ey/sniper/internal/config"
+)
+
+// ---------- Versioned Transactions ----------
+
+// loadWallet parses the base58 encoded secret key from PRIV_KEY_WALLET.
+func loadWallet(privKey string) (solana.PrivateKey, error) {
+	wallet, err := solana.PrivateKeyFromBase58(privKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create keypair: %v", err)
+	}
+	return wallet, nil
+}
+
+// decodeSwapTransaction deserializes the base64 encoded (legacy or v0)
+// transaction returned by the Jupiter swap API.
+func decodeSwapTransaction(swapTransaction string) (*solana.Transaction, error) {
+	tx, err := solana.TransactionFromBase64(swapTransaction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deserialize transaction: %v", err)
+	}
+	return tx, nil
+}
+
+// signTransaction signs tx with the wallet, which must be the fee payer. The
+// signatures are verified against the message bytes before returning.
+func signTransaction(tx *solana.Transaction, wallet solana.PrivateKey) error {
+	signers := tx.Message.Signers()
+	if len(signers) == 0 || !signers[0].Equals(wallet.PublicKey()) {
+		return fmt.Errorf("transaction fee payer is not the wallet %s", wallet.PublicKey())
+	}
+	if _, err := tx.Sign(func(key solana.PublicKey) *solana.PrivateKey {
+		if key.Equals(wallet.PublicKey()) {
+			return &wallet
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to sign transaction: %v", err)
+	}
+	if err := tx.VerifySignatures(); err != nil {
+		return fmt.Errorf("failed to verify transaction signature: %v", err)
+	}
+	return nil
+}
+
+// sendTransaction submits a signed transaction without preflight checks.
+func sendTransaction(ctx context.Context, rpcClient *rpc.Client, tx *solana.Transaction) (solana.Signature, error) {
+	maxRetries := uint(2)
+	return rpcClient.SendTransactionWithOpts(ctx, tx, rpc.TransactionOpts{
+		SkipPreflight: true,
+		MaxRetries:    &maxRetries,
+	})
+}
+
+// BlockhashExpiredError is returned when a transaction was not confirmed before
+// the chain passed its lastValidBlockHeight. The transaction can no longer land,
+// so the caller should request a fresh quote and retry.
+type BlockhashExpiredError struct {
+	Signature            string
+	LastValidBlockHeight uint64
+	BlockHeight          uint64
+}
+
+func (e *BlockhashExpiredError) Error() string {
+	return fmt.Sprintf("blockhash expired for transaction %s: block height %d exceeds last valid block height %d",
+		e.Signature, e.BlockHeight, e.LastValidBlockHeight)
+}
+
+// confirmTransaction polls the signature status until the transaction is
+// confirmed, fails on-chain, its blockhash expires, or ctx is done. A
+// lastValidBlockHeight of 0 disables the expiry check.
+func confirmTransaction(ctx context.Context, rpcClient *rpc.Client, txid solana.Signature, lastValidBlockHeight uint64) error {
+	ticker := time.NewTicker(time.Duration(config.Get().Tx.RetryDelay) * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		statuses, err := rpcClient.GetSignatureStatuses(ctx, false, txid)
+		if err == nil && len(statuses.Value) > 0 && statuses.Value[0] != nil {
+			status := statuses.Value[0]
+			if status.Err != nil {
+				return fmt.Errorf("transaction failed: %v", status.Err)
+			}
+			if status.ConfirmationStatus == rpc.ConfirmationStatusConfirmed ||
+				status.ConfirmationStatus == rpc.ConfirmationStatusFinalized {
+				return nil
+			}
+		} else if err != nil {
+			log.Printf("Could not fetch signature status: %v", err)
+		}
+
+		if lastValidBlockHeight > 0 {
+			blockHeight, err := rpcClient.GetBlockHeight(ctx, rpc.CommitmentConfirmed)
+			if err == nil && blockHeight > lastValidBlockHeight {
+				return &BlockhashExpiredError{
+					Signature:            txid.String(),
+					LastValidBlockHeight: lastValidBlockHeight,
+					BlockHeight:          blockHeight,
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package transactions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// newUnsignedSwapTransaction builds a v0 transaction the way the Jupiter swap
+// API returns it: base64 encoded, referencing an address lookup table, with
+// zeroed signature slots for the fee payer.
+func newUnsignedSwapTransaction(t *testing.T, payer solana.PublicKey) string {
+	t.Helper()
+	table := solana.NewWallet().PublicKey()
+	recipient := solana.NewWallet().PublicKey()
+
+	tx, err := solana.NewTransaction(
+		[]solana.Instruction{
+			system.NewTransferInstruction(1_000_000, payer, recipient).Build(),
+		},
+		solana.MustHashFromBase58("4uQeVj5tqViQh7yWWGStvkEG1Zmhx6uasJtWCJziofM"),
+		solana.TransactionPayer(payer),
+		solana.TransactionAddressTables(map[solana.PublicKey]solana.PublicKeySlice{
+			table: {recipient},
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tx.Message.IsVersioned() || len(tx.Message.AddressTableLookups) == 0 {
+		t.Fatal("expected a v0 transaction with address table lookups")
+	}
+	tx.Signatures = make([]solana.Signature, tx.Message.Header.NumRequiredSignatures)
+
+	encoded, err := tx.ToBase64()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return encoded
+}
+
+func TestSignVersionedSwapTransactionRoundTrip(t *testing.T) {
+	wallet := solana.NewWallet().PrivateKey
+	encoded := newUnsignedSwapTransaction(t, wallet.PublicKey())
+
+	tx, err := decodeSwapTransaction(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := signTransaction(tx, wallet); err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := solana.TransactionFromBytes(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Message.IsVersioned() || len(decoded.Message.AddressTableLookups) != 1 {
+		t.Fatal("versioned message or address table lookups lost in round trip")
+	}
+
+	message, err := decoded.Message.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.Signatures) != 1 {
+		t.Fatalf("got %d signatures, want 1", len(decoded.Signatures))
+	}
+	if !decoded.Signatures[0].Verify(wallet.PublicKey(), message) {
+		t.Fatal("signature does not verify against the message bytes")
+	}
+}
+
+func TestSignTransactionRejectsForeignFeePayer(t *testing.T) {
+	wallet := solana.NewWallet().PrivateKey
+	encoded := newUnsignedSwapTransaction(t, solana.NewWallet().PublicKey())
+
+	tx, err := decodeSwapTransaction(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := signTransaction(tx, wallet); err == nil {
+		t.Fatal("expected an error when the wallet is not the fee payer")
+	}
+}
+
+func TestLoadWalletRejectsPublicKey(t *testing.T) {
+	if _, err := loadWallet(solana.NewWallet().PublicKey().String()); err == nil {
+		t.Fatal("expected an error when PRIV_KEY_WALLET holds a public key")
+	}
+}
+
+func TestConfirmTransaction(t *testing.T) {
+	confirmed := map[string]interface{}{"slot": 1, "confirmations": nil, "err": nil, "confirmationStatus": "confirmed"}
+	tests := []struct {
+		name        string
+		status      interface{}
+		blockHeight uint64
+		check       func(t *testing.T, err error)
+	}{
+		{"confirmed", confirmed, 50, func(t *testing.T, err error) {
+			if err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+		}},
+		{"blockhash expired", nil, 101, func(t *testing.T, err error) {
+			var expired *BlockhashExpiredError
+			if !errors.As(err, &expired) || expired.BlockHeight != 101 || expired.LastValidBlockHeight != 100 {
+				t.Fatalf("got %v, want a BlockhashExpiredError at height 101", err)
+			}
+		}},
+		{"failed on-chain", map[string]interface{}{"slot": 1, "confirmations": nil, "err": map[string]interface{}{"InstructionError": []interface{}{0, "Custom"}}, "confirmationStatus": "confirmed"}, 50, func(t *testing.T, err error) {
+			var expired *BlockhashExpiredError
+			if err == nil || errors.As(err, &expired) {
+				t.Fatalf("got %v, want the transaction error", err)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rpcURL := newRPCStandIn(t, func(method string, params json.RawMessage) interface{} {
+				switch method {
+				case "getSignatureStatuses":
+					return map[string]interface{}{"context": map[string]int{"slot": 1}, "value": []interface{}{tt.status}}
+				case "getBlockHeight":
+					return tt.blockHeight
+				}
+				t.Errorf("unexpected call %s", method)
+				return nil
+			})
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			err := confirmTransaction(ctx, rpc.New(rpcURL), solana.Signature{1}, 100)
+			tt.check(t, err)
+		})
+	}
+}
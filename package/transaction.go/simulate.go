@@ -0,0 +1,138 @@
+package transactions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/low4ey/sniper/package/models"
+	"github.com/low4ey/sniper/package/tracker/db"
+)
+
+// ---------- Simulation ----------
+
+// SimulationFailure classifies why a transaction failed simulation.
+type SimulationFailure string
+
+const (
+	SimulationSlippageExceeded  SimulationFailure = "slippage exceeded"
+	SimulationInsufficientFunds SimulationFailure = "insufficient funds"
+	SimulationBlockhashNotFound SimulationFailure = "blockhash not found"
+	SimulationProgramError      SimulationFailure = "program error"
+)
+
+// SimulationError is returned when a signed transaction fails simulation, or
+// the swap API reports that its own simulation failed. Nothing was sent.
+type SimulationError struct {
+	Failure       SimulationFailure
+	Err           string
+	Logs          []string
+	UnitsConsumed uint64
+}
+
+func (e *SimulationError) Error() string {
+	return fmt.Sprintf("transaction simulation failed (%s): %s", e.Failure, e.Err)
+}
+
+// simulationFailures maps substrings of simulation errors and program logs to
+// the failure they indicate. Custom program errors only count when the failing
+// program is the one that defines them: Jupiter v6 reports slippage as 0x1771
+// (6001), Raydium AMM v4 as 0x1e (30).
+var simulationFailures = []struct {
+	program string
+	match   string
+	failure SimulationFailure
+}{
+	{"", "SlippageToleranceExceeded", SimulationSlippageExceeded},
+	{"", "exceeds desired slippage limit", SimulationSlippageExceeded},
+	{"JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4", "failed: custom program error: 0x1771", SimulationSlippageExceeded},
+	{"675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8", "failed: custom program error: 0x1e", SimulationSlippageExceeded},
+	{"", "InsufficientFunds", SimulationInsufficientFunds},
+	{"", "insufficient lamports", SimulationInsufficientFunds},
+	{"", "insufficient funds", SimulationInsufficientFunds},
+	{"", "BlockhashNotFound", SimulationBlockhashNotFound},
+}
+
+// classifySimulation picks the failure matching errText or, failing that, the
+// program logs.
+func classifySimulation(errText string, logs []string) SimulationFailure {
+	for _, text := range append([]string{errText}, logs...) {
+		for _, f := range simulationFailures {
+			if f.program == "" && strings.Contains(text, f.match) ||
+				f.program != "" && strings.HasPrefix(text, "Program "+f.program) && strings.HasSuffix(text, f.match) {
+				return f.failure
+			}
+		}
+	}
+	return SimulationProgramError
+}
+
+// checkSwapSimulation turns a simulation failure reported by the swap API into
+// a SimulationError.
+func checkSwapSimulation(swapErr *models.SwapSimulationError) error {
+	if swapErr == nil {
+		return nil
+	}
+	errText := swapErr.ErrorCode + ": " + swapErr.Error
+	return &SimulationError{Failure: classifySimulation(errText, nil), Err: errText}
+}
+
+// simulateTransaction runs the signed tx through simulateTransaction, records
+// the result against tokenMint and side ("buy" or "sell"), and returns a
+// *SimulationError if it would fail on-chain.
+func simulateTransaction(ctx context.Context, rpcClient *rpc.Client, tx *solana.Transaction, tokenMint, side string) error {
+	resp, err := rpcClient.SimulateTransactionWithOpts(ctx, tx, &rpc.SimulateTransactionOpts{
+		SigVerify:  true,
+		Commitment: rpc.CommitmentProcessed,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to simulate transaction: %v", err)
+	}
+	result := resp.Value
+	if result == nil {
+		return fmt.Errorf("failed to simulate transaction: empty result")
+	}
+
+	var unitsConsumed uint64
+	if result.UnitsConsumed != nil {
+		unitsConsumed = *result.UnitsConsumed
+	}
+	errText := ""
+	if result.Err != nil {
+		errJSON, _ := json.Marshal(result.Err)
+		errText = string(errJSON)
+	}
+
+	signature := ""
+	if len(tx.Signatures) > 0 {
+		signature = tx.Signatures[0].String()
+	}
+	record := models.SimulationRecord{
+		Time:          int(time.Now().Unix()),
+		Signature:     signature,
+		Token:         tokenMint,
+		Side:          side,
+		UnitsConsumed: int(unitsConsumed),
+		Err:           errText,
+		Logs:          strings.Join(result.Logs, "\n"),
+	}
+	if err := db.InsertSimulation(record); err != nil {
+		log.Printf("⛔ Unable to store simulation result: %v", err)
+	}
+
+	if result.Err != nil {
+		return &SimulationError{
+			Failure:       classifySimulation(errText, result.Logs),
+			Err:           errText,
+			Logs:          result.Logs,
+			UnitsConsumed: unitsConsumed,
+		}
+	}
+	log.Printf("✅ Transaction simulated: %d compute units consumed.", unitsConsumed)
+	return nil
+}
@@ -0,0 +1,159 @@
+package transactions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/low4ey/sniper/package/models"
+	"github.com/low4ey/sniper/package/tracker/db"
+)
+
+func TestClassifySimulation(t *testing.T) {
+	tests := []struct {
+		name    string
+		errText string
+		logs    []string
+		want    SimulationFailure
+	}{
+		{
+			name:    "jupiter slippage",
+			errText: `{"InstructionError":[3,{"Custom":6001}]}`,
+			logs: []string{
+				"Program JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4 invoke [1]",
+				"Program log: AnchorError occurred. Error Code: SlippageToleranceExceeded. Error Number: 6001.",
+				"Program JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4 failed: custom program error: 0x1771",
+			},
+			want: SimulationSlippageExceeded,
+		},
+		{
+			name:    "raydium slippage",
+			errText: `{"InstructionError":[2,{"Custom":30}]}`,
+			logs: []string{
+				"Program 675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8 invoke [1]",
+				"Program 675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8 failed: custom program error: 0x1e",
+			},
+			want: SimulationSlippageExceeded,
+		},
+		{
+			name:    "same code from another program",
+			errText: `{"InstructionError":[2,{"Custom":30}]}`,
+			logs:    []string{"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA failed: custom program error: 0x1e"},
+			want:    SimulationProgramError,
+		},
+		{
+			name:    "insufficient SOL",
+			errText: `"InsufficientFundsForFee"`,
+			want:    SimulationInsufficientFunds,
+		},
+		{
+			name:    "insufficient tokens",
+			errText: `{"InstructionError":[4,{"Custom":1}]}`,
+			logs:    []string{"Program log: Error: insufficient funds"},
+			want:    SimulationInsufficientFunds,
+		},
+		{
+			name:    "expired blockhash",
+			errText: `"BlockhashNotFound"`,
+			want:    SimulationBlockhashNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifySimulation(tt.errText, tt.logs); got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckSwapSimulation(t *testing.T) {
+	if err := checkSwapSimulation(nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := checkSwapSimulation(&models.SwapSimulationError{ErrorCode: "TRANSACTION_ERROR", Error: "Error: insufficient funds"})
+	var simErr *SimulationError
+	if !errors.As(err, &simErr) {
+		t.Fatalf("got %v, want a *SimulationError", err)
+	}
+	if simErr.Failure != SimulationInsufficientFunds {
+		t.Fatalf("got failure %q, want %q", simErr.Failure, SimulationInsufficientFunds)
+	}
+}
+
+func TestSimulateTransaction(t *testing.T) {
+	if err := db.Open(filepath.Join(t.TempDir(), "holdings.db")); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	wallet := solana.NewWallet().PrivateKey
+	tx, err := decodeSwapTransaction(newUnsignedSwapTransaction(t, wallet.PublicKey()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := signTransaction(tx, wallet); err != nil {
+		t.Fatal(err)
+	}
+
+	logs := []string{
+		"Program 675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8 invoke [1]",
+		"Program 675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8 failed: custom program error: 0x1e",
+	}
+	var simulationErr interface{}
+	rpcURL := newRPCStandIn(t, func(method string, params json.RawMessage) interface{} {
+		var args []json.RawMessage
+		var opts struct {
+			SigVerify bool `json:"sigVerify"`
+		}
+		if json.Unmarshal(params, &args) != nil || len(args) != 2 || json.Unmarshal(args[1], &opts) != nil {
+			t.Errorf("malformed params %s", params)
+		}
+		if method != "simulateTransaction" || !opts.SigVerify {
+			t.Errorf("unexpected call %s %s", method, params)
+			return nil
+		}
+		return map[string]interface{}{
+			"context": map[string]int{"slot": 1},
+			"value":   map[string]interface{}{"err": simulationErr, "logs": logs, "unitsConsumed": 12345},
+		}
+	})
+	rpcClient := rpc.New(rpcURL)
+	token := solana.NewWallet().PublicKey().String()
+
+	simulationErr = map[string]interface{}{"InstructionError": []interface{}{2, map[string]int{"Custom": 30}}}
+	err = simulateTransaction(context.Background(), rpcClient, tx, token, "buy")
+	var simErr *SimulationError
+	if !errors.As(err, &simErr) {
+		t.Fatalf("got %v, want a *SimulationError", err)
+	}
+	if simErr.Failure != SimulationSlippageExceeded || simErr.UnitsConsumed != 12345 || len(simErr.Logs) != 2 {
+		t.Fatalf("got %+v, want a slippage failure with the units and logs", simErr)
+	}
+
+	simulationErr = nil
+	if err := simulateTransaction(context.Background(), rpcClient, tx, token, "sell"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records, err := db.SelectSimulationsBySignature(tx.Signatures[0].String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d simulations recorded, want 2", len(records))
+	}
+	failed, passed := records[0], records[1]
+	if failed.Token != token || failed.Side != "buy" || failed.UnitsConsumed != 12345 ||
+		failed.Err != `{"InstructionError":[2,{"Custom":30}]}` || failed.Logs != logs[0]+"\n"+logs[1] {
+		t.Errorf("unexpected failed simulation record %+v", failed)
+	}
+	if passed.Side != "sell" || passed.Err != "" {
+		t.Errorf("unexpected passed simulation record %+v", passed)
+	}
+}
@@ -3,44 +3,48 @@ package transactions
 import (
 	"bytes"
 	"context"
-	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
-	"math"
 	"net/http"
-	"os"
 	"strconv"
-	"strings"
 	"time"
-	"your_project/tracker/db" // import your DB functions
 
 	"github.com/gagliardetto/solana-go"
 	"github.com/gagliardetto/solana-go/rpc"
 	"github.com/low4ey/sniper/internal/config"
+	"github.com/low4ey/sniper/package/httpclient"
+	"github.com/low4ey/sniper/package/jupiter"
 	"github.com/low4ey/sniper/package/models"
-	"github.com/mr-tron/base58"
+	"github.com/low4ey/sniper/package/pumpfun"
+	"github.com/low4ey/sniper/package/raydium"
+	"github.com/low4ey/sniper/package/tracker/db"
 )
 
-func newHTTPClient(timeoutMs int) *http.Client {
-	return &http.Client{
-		Timeout: time.Duration(timeoutMs) * time.Millisecond,
-	}
-}
-
 // ---------- Function: FetchTransactionDetails ----------
 
-func FetchTransactionDetails(signature string) (*models.MintsDataReponse, error) {
-	txUrl := os.Getenv("HELIUS_HTTPS_URI_TX")
-	maxRetries := config.ConfigVal.Tx.FetchTxMaxRetries
-	initialDelay := time.Duration(config.ConfigVal.Tx.FetchTxInitialDelay) * time.Millisecond
+func (c *Client) FetchTransactionDetails(ctx context.Context, signature string) (*models.MintsDataReponse, error) {
+	cfg := config.Get()
+	txUrl := c.env.HeliusHTTPSURITx
+	maxRetries := cfg.Tx.FetchTxMaxRetries
+	initialDelay := time.Duration(cfg.Tx.FetchTxInitialDelay) * time.Millisecond
 
 	log.Printf("Waiting %v seconds for transaction to be confirmed...", initialDelay.Seconds())
-	time.Sleep(initialDelay)
+	if err := sleep(ctx, initialDelay); err != nil {
+		return nil, err
+	}
 
-	client := newHTTPClient(config.ConfigVal.Tx.GetTimeout)
+	// The transaction may not be indexed yet; back off and ask again.
 	retryCount := 0
+	retry := func(reason interface{}) error {
+		log.Printf("Attempt %d failed: %v", retryCount+1, reason)
+		retryCount++
+		delay := httpclient.Backoff(retryCount, 4*time.Second, time.Duration(cfg.Tx.HTTPMaxRetryDelay)*time.Millisecond)
+		log.Printf("Waiting %v seconds before next attempt...", delay.Seconds())
+		return sleep(ctx, delay)
+	}
 
 	for retryCount < maxRetries {
 		log.Printf("Attempt %d of %d to fetch transaction details...", retryCount+1, maxRetries)
@@ -50,87 +54,62 @@ func FetchTransactionDetails(signature string) (*models.MintsDataReponse, error)
 			"encoding":     "jsonParsed",
 		}
 		payloadBytes, _ := json.Marshal(payload)
-		req, err := http.NewRequest("POST", txUrl, bytes.NewReader(payloadBytes))
+		req, err := http.NewRequestWithContext(ctx, "POST", txUrl, bytes.NewReader(payloadBytes))
 		if err != nil {
 			return nil, err
 		}
 		req.Header.Set("Content-Type", "application/json")
 
-		resp, err := client.Do(req)
+		resp, err := c.httpClient.Do(req)
 		if err != nil {
-			log.Printf("Attempt %d failed: %v", retryCount+1, err)
-			retryCount++
-			delay := time.Duration(min(4000*pow(1.5, float64(retryCount)), 15000)) * time.Millisecond
-			log.Printf("Waiting %v seconds before next attempt...", delay.Seconds())
-			time.Sleep(delay)
+			if err := retry(err); err != nil {
+				return nil, err
+			}
 			continue
 		}
-		defer resp.Body.Close()
-
 		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
 		if err != nil {
 			return nil, err
 		}
 		var transactions []models.TransactionDetailResponse
 		if err := json.Unmarshal(body, &transactions); err != nil {
-			log.Printf("Attempt %d failed: %v", retryCount+1, err)
-			retryCount++
-			delay := time.Duration(min(4000*pow(1.5, float64(retryCount)), 15000)) * time.Millisecond
-			log.Printf("Waiting %v seconds before next attempt...", delay.Seconds())
-			time.Sleep(delay)
+			if err := retry(err); err != nil {
+				return nil, err
+			}
 			continue
 		}
 
 		if len(transactions) == 0 || len(transactions[0].Instructions) == 0 {
-			log.Printf("Attempt %d failed: transaction or instructions not found", retryCount+1)
-			retryCount++
-			delay := time.Duration(min(4000*pow(1.5, float64(retryCount)), 15000)) * time.Millisecond
-			log.Printf("Waiting %v seconds before next attempt...", delay.Seconds())
-			time.Sleep(delay)
+			if err := retry("transaction or instructions not found"); err != nil {
+				return nil, err
+			}
 			continue
 		}
 
-		// Find instruction for the liquidity pool program.
-		targetInsts := transactions[0].Instructions
-		var targetInst *models.Instructions
-		for _, inst := range targetInsts {
-			if inst.ProgramId == config.ConfigVal.LiquidityPool.RadiyumProgramID {
-				targetInst = &inst
-				break
+		pool, err := findPoolCreation(transactions[0].Instructions, PoolPrograms(cfg.LiquidityPool))
+		if err != nil {
+			if err := retry(err); err != nil {
+				return nil, err
 			}
-		}
-		if targetInst == nil || len(targetInst.Accounts) < 10 {
-			log.Printf("Attempt %d failed: no valid market maker instruction found", retryCount+1)
-			retryCount++
-			delay := time.Duration(min(4000*pow(1.5, float64(retryCount)), 15000)) * time.Millisecond
-			log.Printf("Waiting %v seconds before next attempt...", delay.Seconds())
-			time.Sleep(delay)
 			continue
 		}
 
-		// Extract accounts 8 and 9.
-		accountOne := targetInst.Accounts[8]
-		accountTwo := targetInst.Accounts[9]
-		if accountOne == "" || accountTwo == "" {
-			return nil, fmt.Errorf("required accounts not found")
-		}
-
-		var solTokenAccount, newTokenAccount string
-		if accountOne == config.ConfigVal.LiquidityPool.WsolPcMint {
-			solTokenAccount = accountOne
-			newTokenAccount = accountTwo
-		} else {
-			solTokenAccount = accountTwo
-			newTokenAccount = accountOne
+		solMint, tokenMint := pool.QuoteMint.String(), pool.BaseMint.String()
+		if pool.BaseMint.String() == cfg.LiquidityPool.WsolPcMint {
+			solMint, tokenMint = tokenMint, solMint
 		}
 
 		log.Printf("Successfully fetched transaction details!")
-		log.Printf("SOL Token Account: %s", solTokenAccount)
-		log.Printf("New Token Account: %s", newTokenAccount)
+		log.Printf("SOL Token Account: %s", solMint)
+		log.Printf("New Token Account: %s", tokenMint)
+		log.Printf("Pool: %s %s (reserves %d base / %d quote)", pool.Type, pool.ID, pool.InitBaseReserve, pool.InitQuoteReserve)
 
 		return &models.MintsDataReponse{
-			TokenMint: newTokenAccount,
-			SolMint:   solTokenAccount,
+			TokenMint: tokenMint,
+			SolMint:   solMint,
+			Pool:      pool,
+			PumpFun:   pumpfun.IsPumpFun(tokenMint, &transactions[0]),
 		}, nil
 	}
 
@@ -138,61 +117,76 @@ func FetchTransactionDetails(signature string) (*models.MintsDataReponse, error)
 	return nil, fmt.Errorf("failed to fetch transaction details")
 }
 
-// ---------- Function: CreateSwapTransaction ----------
-
-func CreateSwapTransaction(solMint, tokenMint string) (string, error) {
-	quoteUrl := os.Getenv("JUP_HTTPS_QUOTE_URI")
-	swapUrl := os.Getenv("JUP_HTTPS_SWAP_URI")
-	rpcUrl := os.Getenv("HELIUS_HTTPS_URI")
-
-	client := newHTTPClient(config.ConfigVal.Tx.GetTimeout)
-	var quoteResponseData *models.QuoteResponse
-
-	// Create a Solana RPC client.
-	rpcClient := rpc.New(rpcUrl)
-
-	// Create wallet from secret key.
-	privKeyStr := os.Getenv("PRIV_KEY_WALLET")
-	walletPubKey, err := solana.PublicKeyFromBase58(privKeyStr)
-	if err != nil {
-		return "", fmt.Errorf("failed to create keypair: %v", err)
+// PoolPrograms maps the id of every pool program configured in lp to the
+// type of the pools it creates.
+func PoolPrograms(lp config.LiquidityPoolConfig) map[string]raydium.PoolType {
+	programs := map[string]raydium.PoolType{lp.RadiyumProgramID: raydium.AmmV4}
+	if lp.RaydiumCpmmProgramID != "" {
+		programs[lp.RaydiumCpmmProgramID] = raydium.Cpmm
 	}
-	// --- Get Swap Quote ---
-	retryCount := 0
-	maxRetries := config.ConfigVal.Swap.TokenNotTradable400ErrorRetries
-	for retryCount < maxRetries {
-		reqURL := fmt.Sprintf("%s?inputMint=%s&outputMint=%s&amount=%s&slippageBps=%s", quoteUrl, solMint, tokenMint, config.ConfigVal.Swap.Amount, config.ConfigVal.Swap.SlippageBps)
-		resp, err := client.Get(reqURL)
-		if err != nil {
-			log.Printf("Swap quote attempt %d failed: %v", retryCount+1, err)
-			retryCount++
-			time.Sleep(time.Duration(config.ConfigVal.Swap.TokenNotTradable400ErrorDelay) * time.Millisecond)
+	if lp.RaydiumClmmProgramID != "" {
+		programs[lp.RaydiumClmmProgramID] = raydium.Clmm
+	}
+	return programs
+}
+
+// findPoolCreation returns the pool created by the first pool creation
+// instruction of one of programs among instructions.
+func findPoolCreation(instructions []models.Instructions, programs map[string]raydium.PoolType) (*raydium.Pool, error) {
+	err := fmt.Errorf("no valid market maker instruction found")
+	for _, inst := range instructions {
+		poolType, ok := programs[inst.ProgramId]
+		if !ok {
 			continue
 		}
-		body, err := ioutil.ReadAll(resp.Body)
-		resp.Body.Close()
-		if err != nil {
-			return "", err
+		pool, decodeErr := raydium.ParseCreation(poolType, inst.Accounts, inst.Data)
+		if decodeErr == nil {
+			return pool, nil
 		}
-		if err := json.Unmarshal(body, &quoteResponseData); err != nil {
-			// Check if error indicates TOKEN_NOT_TRADABLE (this logic may vary based on actual response)
-			if resp.StatusCode == 400 {
-				retryCount++
-				time.Sleep(time.Duration(config.ConfigVal.Swap.TokenNotTradable400ErrorDelay) * time.Millisecond)
-				continue
-			}
+		err = fmt.Errorf("no valid market maker instruction found: %v", decodeErr)
+	}
+	return nil, err
+}
+
+// ---------- Function: CreateSwapTransaction ----------
+
+func (c *Client) CreateSwapTransaction(ctx context.Context, solMint, tokenMint string) (string, error) {
+	cfg := config.Get()
+	swapUrl := c.env.JupHTTPSSwapURI
+
+	// In simulation mode the quote is recorded as a paper fill; no wallet needed.
+	simulate := cfg.RugCheck.SimulationMode
+	rpcClient := c.rpcClient
+	var wallet solana.PrivateKey
+	if !simulate {
+		var err error
+		wallet, err = c.requireWallet()
+		if err != nil {
 			return "", err
 		}
-		log.Printf("✅ Swap quote received.")
-		break
 	}
-	if quoteResponseData == nil {
-		return "", fmt.Errorf("failed to get swap quote")
+
+	// --- Get Swap Quote ---
+	quote, err := c.quoteWithRetry(ctx, solMint, tokenMint, cfg.Swap.Amount, cfg.Swap.SlippageBps)
+	if err != nil {
+		return "", fmt.Errorf("failed to get swap quote: %w", err)
+	}
+	log.Printf("✅ Swap quote received: %d out, %.2f%% price impact.", quote.OutAmount, quote.PriceImpactPct*100)
+	if err := guardQuote(&quote.QuoteResponse, cfg.Swap); err != nil {
+		log.Printf("🚫 Swap quote rejected: %v", err)
+		return "", err
+	}
+	if simulate {
+		if err := c.paperBuy(ctx, tokenMint, &quote.QuoteResponse); err != nil {
+			return "", fmt.Errorf("failed to record paper buy: %v", err)
+		}
+		return SimulatedTx, nil
 	}
+	walletPubKey := wallet.PublicKey()
 
 	// --- Serialize the Quote into a Swap Transaction ---
 	swapPayload := map[string]interface{}{
-		"quoteResponse":    quoteResponseData,
+		"quoteResponse":    quote.Raw,
 		"userPublicKey":    walletPubKey.String(),
 		"wrapAndUnwrapSol": true,
 		"dynamicSlippage": map[string]interface{}{
@@ -200,18 +194,18 @@ func CreateSwapTransaction(solMint, tokenMint string) (string, error) {
 		},
 		"prioritizationFeeLamports": map[string]interface{}{
 			"priorityLevelWithMaxLamports": map[string]interface{}{
-				"maxLamports":   config.ConfigVal.Swap.PrioFeeMaxLamports,
-				"priorityLevel": config.ConfigVal.Swap.PrioLevel,
+				"maxLamports":   cfg.Swap.PrioFeeMaxLamports,
+				"priorityLevel": cfg.Swap.PrioLevel,
 			},
 		},
 	}
 	swapPayloadBytes, _ := json.Marshal(swapPayload)
-	req, err := http.NewRequest("POST", swapUrl, bytes.NewReader(swapPayloadBytes))
+	req, err := http.NewRequestWithContext(ctx, "POST", swapUrl, bytes.NewReader(swapPayloadBytes))
 	if err != nil {
 		return "", err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	resp, err := client.Do(req)
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return "", err
 	}
@@ -225,68 +219,48 @@ func CreateSwapTransaction(solMint, tokenMint string) (string, error) {
 		return "", err
 	}
 	log.Printf("✅ Swap quote serialized.")
-
-	//-------------------------------------------------------
-	//   Work From Here 									|
-	//------------------------------------------------------|
-
-	// --- Deserialize, Sign and Send Transaction ---
-	swapTxBytes, err := base58.Decode(serializedQuoteResponse.SwapTransaction)
-	if err != nil {
-		// If the transaction is base64 encoded instead, use:
-		swapTxBytes, err = decodeBase64(serializedQuoteResponse.SwapTransaction)
-		if err != nil {
-			return "", fmt.Errorf("failed to decode swap transaction: %v", err)
-		}
-	}
-	// Deserialize versioned transaction (solana-go supports legacy transactions; for versioned ones, additional work may be needed)
-	tx, err := solana.TransactionFromDecoder()
-	if err != nil {
-		return "", fmt.Errorf("failed to deserialize transaction: %v", err)
+	if err := checkSwapSimulation(serializedQuoteResponse.SimulationError); err != nil {
+		return "", err
 	}
-	// Sign the transaction
-	tx.Signatures = append(tx.Signatures, solana.Signature{}, solana.Signature{}) // placeholder; proper signing logic depends on tx type
-	// (In practice, use the appropriate Sign method provided by the SDK.)
-	// Here we assume tx.SignPartial(keypair) exists.
-	// tx.SignPartial(keypair)
 
-	// Get recent blockhash.
-	ctx := context.Background()
-	recent, err := rpcClient.GetLatestBlockhash(ctx)
+	// --- Deserialize, Sign, Simulate and Send Transaction ---
+	tx, err := decodeSwapTransaction(serializedQuoteResponse.SwapTransaction)
 	if err != nil {
 		return "", err
 	}
-	// Set the recent blockhash in tx (if needed).
-	// tx.Message.RecentBlockhash = recent.Blockhash
-
-	// Serialize and send transaction.
-	rawTx, err := tx.Serialize()
-	if err != nil {
+	if err := signTransaction(tx, wallet); err != nil {
+		return "", err
+	}
+	if err := simulateTransaction(ctx, rpcClient, tx, tokenMint, "buy"); err != nil {
 		return "", err
 	}
-	txid, err := rpcClient.SendRawTransaction(ctx, rawTx)
+
+	txid, err := sendTransaction(ctx, rpcClient, tx)
 	if err != nil {
-		return "", fmt.Errorf("failed to send transaction: %v", err)
+		return "", fmt.Errorf("failed to send transaction: %w", err)
 	}
 	log.Printf("✅ Raw transaction id received: %s", txid)
 
 	// Confirm transaction.
 	confirmCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
-	_, err = rpcClient.ConfirmTransaction(confirmCtx, txid)
-	if err != nil {
-		return "", fmt.Errorf("transaction confirmation failed: %v", err)
+	if err := confirmTransaction(confirmCtx, rpcClient, txid, uint64(serializedQuoteResponse.LastValidBlockHeight)); err != nil {
+		return "", fmt.Errorf("transaction confirmation failed: %w", err)
 	}
 	log.Printf("Transaction confirmed.")
-	return txid, nil
+	return txid.String(), nil
 }
 
 // ---------- Function: GetRugCheckConfirmed ----------
 
-func GetRugCheckConfirmed(tokenMint string) (bool, error) {
-	rugUrl := fmt.Sprintf("https://api.rugcheck.xyz/v1/tokens/%s/report", tokenMint)
-	client := newHTTPClient(config.ConfigVal.Tx.GetTimeout)
-	resp, err := client.Get(rugUrl)
+func (c *Client) GetRugCheckConfirmed(ctx context.Context, tokenMint string) (bool, error) {
+	cfg := config.Get()
+	rugUrl := fmt.Sprintf(rugCheckURL, tokenMint)
+	req, err := http.NewRequestWithContext(ctx, "GET", rugUrl, nil)
+	if err != nil {
+		return false, err
+	}
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return false, err
 	}
@@ -295,11 +269,11 @@ func GetRugCheckConfirmed(tokenMint string) (bool, error) {
 	if err != nil {
 		return false, err
 	}
-	var tokenReport RugResponseExtended
+	var tokenReport models.RugResponse
 	if err := json.Unmarshal(body, &tokenReport); err != nil {
 		return false, err
 	}
-	if config.ConfigVal.RugCheck.VerboseLog {
+	if cfg.RugCheck.VerboseLog {
 		log.Printf("%+v", tokenReport)
 	}
 
@@ -322,10 +296,10 @@ func GetRugCheckConfirmed(tokenMint string) (bool, error) {
 	isRugged := tokenReport.Rugged
 	rugScore := tokenReport.Score
 	rugRisks := tokenReport.Risks
-	rugCheckLegacy := config.ConfigVal.RugCheck.LegacyNotAllowed
+	rugCheckLegacy := cfg.RugCheck.LegacyNotAllowed
 
 	// Exclude liquidity pools from top holders if configured.
-	if config.ConfigVal.RugCheck.ExcludeLPFromTopholders && tokenReport.Markets != nil {
+	if cfg.RugCheck.ExcludeLPFromTopholders && tokenReport.Markets != nil {
 		var liquidityAddresses []string
 		for _, market := range tokenReport.Markets {
 			if market.LiquidityA != "" {
@@ -335,7 +309,7 @@ func GetRugCheckConfirmed(tokenMint string) (bool, error) {
 				liquidityAddresses = append(liquidityAddresses, market.LiquidityB)
 			}
 		}
-		filtered := make([]Holder, 0, len(topHolders))
+		filtered := make([]models.Holder, 0, len(topHolders))
 		for _, holder := range topHolders {
 			exclude := false
 			for _, addr := range liquidityAddresses {
@@ -356,32 +330,32 @@ func GetRugCheckConfirmed(tokenMint string) (bool, error) {
 		Check   bool
 		Message string
 	}{
-		{!config.ConfigVal.RugCheck.AllowMintAuthority && mintAuthority != nil, "🚫 Mint authority should be null"},
-		{!config.ConfigVal.RugCheck.AllowNotInitialized && !isInitialized, "🚫 Token is not initialized"},
-		{!config.ConfigVal.RugCheck.AllowFreezeAuthority && freezeAuthority != nil, "🚫 Freeze authority should be null"},
-		{!config.ConfigVal.RugCheck.AllowMutable && tokenMutable, "🚫 Mutable should be false"},
-		{!config.ConfigVal.RugCheck.AllowInsiderTopholders && containsInsider(topHolders), "🚫 Insider accounts should not be part of the top holders"},
-		{anyHolderExceeds(topHolders, config.ConfigVal.RugCheck.MaxAlowedPctTopholders), "🚫 A top holder exceeds the allowed percentage"},
-		{totalLPProviders < config.ConfigVal.RugCheck.MinTotalLPProviders, "🚫 Not enough LP Providers."},
-		{marketsLength < config.ConfigVal.RugCheck.MinTotalMarkets, "🚫 Not enough Markets."},
-		{totalMarketLiquidity < config.ConfigVal.RugCheck.MinTotalMarketLiquidity, "🚫 Not enough Market Liquidity."},
-		{!config.ConfigVal.RugCheck.AllowRugged && isRugged, "🚫 Token is rugged"},
-		{contains(config.ConfigVal.RugCheck.BlockSymbols, tokenReport.TokenMeta.Symbol), "🚫 Symbol is blocked"},
-		{contains(config.ConfigVal.RugCheck.BlockNames, tokenReport.TokenMeta.Name), "🚫 Name is blocked"},
-		{rugScore > config.ConfigVal.RugCheck.MaxScore && config.ConfigVal.RugCheck.MaxScore != 0, "🚫 Rug score too high."},
+		{!cfg.RugCheck.AllowMintAuthority && mintAuthority != nil, "🚫 Mint authority should be null"},
+		{!cfg.RugCheck.AllowNotInitialized && !isInitialized, "🚫 Token is not initialized"},
+		{!cfg.RugCheck.AllowFreezeAuthority && freezeAuthority != nil, "🚫 Freeze authority should be null"},
+		{!cfg.RugCheck.AllowMutable && tokenMutable, "🚫 Mutable should be false"},
+		{!cfg.RugCheck.AllowInsiderTopholders && containsInsider(topHolders), "🚫 Insider accounts should not be part of the top holders"},
+		{anyHolderExceeds(topHolders, cfg.RugCheck.MaxAlowedPctTopholders), "🚫 A top holder exceeds the allowed percentage"},
+		{totalLPProviders < cfg.RugCheck.MinTotalLPProviders, "🚫 Not enough LP Providers."},
+		{marketsLength < cfg.RugCheck.MinTotalMarkets, "🚫 Not enough Markets."},
+		{totalMarketLiquidity < float64(cfg.RugCheck.MinTotalMarketLiquidity), "🚫 Not enough Market Liquidity."},
+		{!cfg.RugCheck.AllowRugged && isRugged, "🚫 Token is rugged"},
+		{contains(cfg.RugCheck.BlockSymbols, tokenReport.TokenMeta.Symbol), "🚫 Symbol is blocked"},
+		{contains(cfg.RugCheck.BlockNames, tokenReport.TokenMeta.Name), "🚫 Name is blocked"},
+		{rugScore > cfg.RugCheck.MaxScore && cfg.RugCheck.MaxScore != 0, "🚫 Rug score too high."},
 		{anyRiskInLegacy(rugRisks, rugCheckLegacy), "🚫 Token has legacy risks that are not allowed."},
 	}
 
 	// If tracking duplicate tokens is enabled, query DB.
-	if config.ConfigVal.RugCheck.BlockReturningTokenNames || config.ConfigVal.RugCheck.BlockReturningTokenCreators {
+	if cfg.RugCheck.BlockReturningTokenNames || cfg.RugCheck.BlockReturningTokenCreators {
 		duplicates, err := db.SelectTokenByNameAndCreator(tokenReport.TokenMeta.Name, tokenCreator)
 		if err == nil && len(duplicates) > 0 {
 			for _, token := range duplicates {
-				if config.ConfigVal.RugCheck.BlockReturningTokenNames && token.Name == tokenReport.TokenMeta.Name {
+				if cfg.RugCheck.BlockReturningTokenNames && token.Name == tokenReport.TokenMeta.Name {
 					log.Println("🚫 Token with this name was already created")
 					return false, nil
 				}
-				if config.ConfigVal.RugCheck.BlockReturningTokenCreators && token.Creator == tokenCreator {
+				if cfg.RugCheck.BlockReturningTokenCreators && token.Creator == tokenCreator {
 					log.Println("🚫 Token from this creator was already created")
 					return false, nil
 				}
@@ -390,8 +364,8 @@ func GetRugCheckConfirmed(tokenMint string) (bool, error) {
 	}
 
 	// Create new token record.
-	newToken := NewTokenRecord{
-		Time:    time.Now().UnixMilli(),
+	newToken := models.NewTokenRecord{
+		Time:    int(time.Now().UnixMilli()),
 		Mint:    tokenMint,
 		Name:    tokenReport.TokenMeta.Name,
 		Creator: tokenCreator,
@@ -412,23 +386,21 @@ func GetRugCheckConfirmed(tokenMint string) (bool, error) {
 
 // ---------- Function: FetchAndSaveSwapDetails ----------
 
-func FetchAndSaveSwapDetails(tx string) (bool, error) {
-	txUrl := os.Getenv("HELIUS_HTTPS_URI_TX")
-	priceUrl := os.Getenv("JUP_HTTPS_PRICE_URI")
-	rpcUrl := os.Getenv("HELIUS_HTTPS_URI")
-	client := newHTTPClient(10000) // hardcoded timeout; adjust as needed
+func (c *Client) FetchAndSaveSwapDetails(ctx context.Context, tx string) (bool, error) {
+	cfg := config.Get()
+	txUrl := c.env.HeliusHTTPSURITx
 
 	// POST to get transaction details.
 	payload := map[string]interface{}{
 		"transactions": []string{tx},
 	}
 	payloadBytes, _ := json.Marshal(payload)
-	req, err := http.NewRequest("POST", txUrl, bytes.NewReader(payloadBytes))
+	req, err := http.NewRequestWithContext(ctx, "POST", txUrl, bytes.NewReader(payloadBytes))
 	if err != nil {
 		return false, err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	resp, err := client.Do(req)
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		log.Printf("⛔ Could not fetch swap details: %v", err)
 		return false, err
@@ -438,23 +410,23 @@ func FetchAndSaveSwapDetails(tx string) (bool, error) {
 	if err != nil {
 		return false, err
 	}
-	var transactions []TransactionDetails
+	var transactions []models.TransactionDetailResponse
 	if err := json.Unmarshal(body, &transactions); err != nil || len(transactions) == 0 {
 		log.Println("⛔ Could not fetch swap details: invalid response")
 		return false, fmt.Errorf("invalid response")
 	}
 	// Assume the first transaction holds our swap details.
 	swapEvent := transactions[0]
+	if len(swapEvent.Events.Swap.InnerSwaps) == 0 {
+		log.Println("⛔ Could not fetch swap details: no swap event found")
+		return false, fmt.Errorf("no swap event found")
+	}
 	innerSwap := swapEvent.Events.Swap.InnerSwaps[0]
-	swapData := struct {
-		ProgramInfo  *ProgramInfo  `json:"programInfo"`
-		TokenInputs  []TokenAmount `json:"tokenInputs"`
-		TokenOutputs []TokenAmount `json:"tokenOutputs"`
-		Fee          int           `json:"fee"`
-		Slot         int           `json:"slot"`
-		Timestamp    int64         `json:"timestamp"`
-		Description  string        `json:"description"`
-	}{
+	if len(innerSwap.TokenInputs) == 0 || len(innerSwap.TokenOutputs) == 0 {
+		log.Println("⛔ Could not fetch swap details: swap event has no token transfers")
+		return false, fmt.Errorf("incomplete swap event")
+	}
+	swapData := models.SwapEventDetailsResponse{
 		ProgramInfo:  innerSwap.ProgramInfo,
 		TokenInputs:  innerSwap.TokenInputs,
 		TokenOutputs: innerSwap.TokenOutputs,
@@ -464,34 +436,11 @@ func FetchAndSaveSwapDetails(tx string) (bool, error) {
 		Description:  swapEvent.Description,
 	}
 
-	// Get latest SOL price from priceUrl.
-	reqPrice, err := http.NewRequest("GET", priceUrl, nil)
+	// Get latest SOL price.
+	solPrice, err := c.solPrice.PriceUSD(ctx, cfg.LiquidityPool.WsolPcMint)
 	if err != nil {
 		return false, err
 	}
-	q := reqPrice.URL.Query()
-	q.Add("ids", config.ConfigVal.LiquidityPool.WsolPcMint)
-	reqPrice.URL.RawQuery = q.Encode()
-	respPrice, err := client.Do(reqPrice)
-	if err != nil {
-		return false, err
-	}
-	priceBody, err := ioutil.ReadAll(respPrice.Body)
-	respPrice.Body.Close()
-	if err != nil {
-		return false, err
-	}
-	var priceResp map[string]map[string]struct {
-		Price float64 `json:"price"`
-	}
-	if err := json.Unmarshal(priceBody, &priceResp); err != nil {
-		return false, err
-	}
-	priceData, ok := priceResp["data"][config.ConfigVal.LiquidityPool.WsolPcMint]
-	if !ok || priceData.Price == 0 {
-		return false, fmt.Errorf("price not found")
-	}
-	solPrice := priceData.Price
 
 	// Calculate estimated prices.
 	solPaidUSDC := swapData.TokenInputs[0].TokenAmount * solPrice
@@ -505,13 +454,13 @@ func FetchAndSaveSwapDetails(tx string) (bool, error) {
 		tokenName = tokens[0].Name
 	}
 
-	newHolding := HoldingRecord{
+	newHolding := models.HoldingRecord{
 		Time:             swapData.Timestamp,
 		Token:            swapData.TokenOutputs[0].Mint,
 		TokenName:        tokenName,
 		Balance:          swapData.TokenOutputs[0].TokenAmount,
 		SolPaid:          swapData.TokenInputs[0].TokenAmount,
-		SolFeePaid:       swapData.Fee,
+		SolFeePaid:       float64(swapData.Fee),
 		SolPaidUSDC:      solPaidUSDC,
 		SolFeePaidUSDC:   solFeePaidUSDC,
 		PerTokenPaidUSDC: perTokenUSDC,
@@ -526,77 +475,55 @@ func FetchAndSaveSwapDetails(tx string) (bool, error) {
 
 // ---------- Function: CreateSellTransaction ----------
 
-func CreateSellTransaction(solMint, tokenMint, amount string) (*CreateSellTransactionResponse, error) {
-	quoteUrl := os.Getenv("JUP_HTTPS_QUOTE_URI")
-	swapUrl := os.Getenv("JUP_HTTPS_SWAP_URI")
-	rpcUrl := os.Getenv("HELIUS_HTTPS_URI")
-
-	// Create Solana RPC client.
-	rpcClient := rpc.New(rpcUrl)
-	privKeyStr := os.Getenv("PRIV_KEY_WALLET")
-	privKeyBytes, err := base58.Decode(privKeyStr)
-	if err != nil {
-		return &CreateSellTransactionResponse{Success: false, Msg: err.Error()}, err
-	}
-	keypair, err := solana.PrivateKeyFromBytes(privKeyBytes)
-	if err != nil {
-		return &CreateSellTransactionResponse{Success: false, Msg: err.Error()}, err
-	}
-	walletPubKey := keypair.PublicKey()
+func (c *Client) CreateSellTransaction(ctx context.Context, solMint, tokenMint, amount string) (*models.CreateSellTransactionResponse, error) {
+	cfg := config.Get()
+	swapUrl := c.env.JupHTTPSSwapURI
+
+	// In simulation mode the quote closes the paper holding; no wallet needed.
+	simulate := cfg.RugCheck.SimulationMode
+	rpcClient := c.rpcClient
+	var wallet solana.PrivateKey
+	if !simulate {
+		var err error
+		wallet, err = c.requireWallet()
+		if err != nil {
+			return &models.CreateSellTransactionResponse{Success: false, Msg: err.Error()}, err
+		}
 
-	// Check token balance.
-	ctx := context.Background()
-	parsedMint, err := solana.PublicKeyFromString(tokenMint)
-	if err != nil {
-		return &CreateSellTransactionResponse{Success: false, Msg: err.Error()}, err
-	}
-	tokenAccounts, err := rpcClient.GetTokenAccountsByOwner(ctx, walletPubKey, rpc.GetTokenAccountsConfig{
-		Mint: parsedMint.String(),
-	})
-	if err != nil {
-		return &CreateSellTransactionResponse{Success: false, Msg: err.Error()}, err
-	}
-	totalBalance := uint64(0)
-	for _, acc := range tokenAccounts.Value {
-		// Assume the parsed JSON has "tokenAmount" as a string; adjust as necessary.
-		amountStr := acc.Account.Data.Parsed.Info.TokenAmount.Amount
-		balance, _ := strconv.ParseUint(amountStr, 10, 64)
-		totalBalance += balance
-	}
-	amountUint, _ := strconv.ParseUint(amount, 10, 64)
-	if totalBalance == 0 || totalBalance != amountUint {
-		// Remove holding from DB.
-		_ = db.RemoveHolding(tokenMint)
-		return &CreateSellTransactionResponse{
-			Success: false,
-			Msg:     "Token balance mismatch or zero balance; sell manually.",
-			Tx:      "",
-		}, fmt.Errorf("balance error")
+		// Check token balance.
+		totalBalance, err := c.tokenBalance(ctx, wallet.PublicKey(), tokenMint)
+		if err != nil {
+			return &models.CreateSellTransactionResponse{Success: false, Msg: err.Error()}, err
+		}
+		amountUint, _ := strconv.ParseUint(amount, 10, 64)
+		if totalBalance == 0 || totalBalance != amountUint {
+			// Remove holding from DB.
+			_ = db.RemoveHolding(tokenMint)
+			return &models.CreateSellTransactionResponse{
+				Success: false,
+				Msg:     "Token balance mismatch or zero balance; sell manually.",
+				Tx:      "",
+			}, fmt.Errorf("balance error")
+		}
 	}
 
 	// Request a sell quote.
-	client := newHTTPClient(config.ConfigVal.Tx.GetTimeout)
-	reqURL := fmt.Sprintf("%s?inputMint=%s&outputMint=%s&amount=%s&slippageBps=%s", quoteUrl, tokenMint, solMint, amount, config.ConfigVal.Sell.SlippageBps)
-	resp, err := client.Get(reqURL)
-	if err != nil {
-		return &CreateSellTransactionResponse{Success: false, Msg: err.Error()}, err
-	}
-	body, err := ioutil.ReadAll(resp.Body)
-	resp.Body.Close()
+	quote, err := c.quoteWithRetry(ctx, tokenMint, solMint, amount, cfg.Sell.SlippageBps)
 	if err != nil {
-		return &CreateSellTransactionResponse{Success: false, Msg: err.Error()}, err
-	}
-	var quoteResp QuoteResponse
-	if err := json.Unmarshal(body, &quoteResp); err != nil {
-		return &CreateSellTransactionResponse{Success: false, Msg: err.Error()}, err
+		var apiErr *jupiter.APIError
+		if errors.As(err, &apiErr) {
+			return &models.CreateSellTransactionResponse{Success: false, Msg: "No valid quote received: " + apiErr.Message}, nil
+		}
+		return &models.CreateSellTransactionResponse{Success: false, Msg: err.Error()}, err
 	}
-	if quoteResp == nil {
-		return &CreateSellTransactionResponse{Success: false, Msg: "No valid quote received"}, nil
+	if simulate {
+		return c.paperSell(tokenMint, &quote.QuoteResponse)
 	}
+	walletPubKey := wallet.PublicKey()
 
 	// Serialize the quote into a swap transaction.
 	swapPayload := map[string]interface{}{
-		"quoteResponse":    quoteResp,
+		"quoteResponse":    quote.Raw,
 		"userPublicKey":    walletPubKey.String(),
 		"wrapAndUnwrapSol": true,
 		"dynamicSlippage": map[string]interface{}{
@@ -604,89 +531,119 @@ func CreateSellTransaction(solMint, tokenMint, amount string) (*CreateSellTransa
 		},
 		"prioritizationFeeLamports": map[string]interface{}{
 			"priorityLevelWithMaxLamports": map[string]interface{}{
-				"maxLamports":   config.ConfigVal.Sell.PrioFeeMaxLamports,
-				"priorityLevel": config.ConfigVal.Sell.PrioLevel,
+				"maxLamports":   cfg.Sell.PrioFeeMaxLamports,
+				"priorityLevel": cfg.Sell.PrioLevel,
 			},
 		},
 	}
 	swapPayloadBytes, _ := json.Marshal(swapPayload)
-	reqSwap, err := http.NewRequest("POST", swapUrl, bytes.NewReader(swapPayloadBytes))
+	reqSwap, err := http.NewRequestWithContext(ctx, "POST", swapUrl, bytes.NewReader(swapPayloadBytes))
 	if err != nil {
-		return &CreateSellTransactionResponse{Success: false, Msg: err.Error()}, err
+		return &models.CreateSellTransactionResponse{Success: false, Msg: err.Error()}, err
 	}
 	reqSwap.Header.Set("Content-Type", "application/json")
-	respSwap, err := client.Do(reqSwap)
+	respSwap, err := c.httpClient.Do(reqSwap)
 	if err != nil {
-		return &CreateSellTransactionResponse{Success: false, Msg: err.Error()}, err
+		return &models.CreateSellTransactionResponse{Success: false, Msg: err.Error()}, err
 	}
 	swapBody, err := ioutil.ReadAll(respSwap.Body)
 	respSwap.Body.Close()
 	if err != nil {
-		return &CreateSellTransactionResponse{Success: false, Msg: err.Error()}, err
+		return &models.CreateSellTransactionResponse{Success: false, Msg: err.Error()}, err
 	}
-	var serializedSwap SerializedQuoteResponse
+	var serializedSwap models.SerializedQuoteResponse
 	if err := json.Unmarshal(swapBody, &serializedSwap); err != nil {
-		return &CreateSellTransactionResponse{Success: false, Msg: err.Error()}, err
-	}
-	// Deserialize transaction.
-	swapTxBytes, err := base58.Decode(serializedSwap.SwapTransaction)
-	if err != nil {
-		swapTxBytes, err = decodeBase64(serializedSwap.SwapTransaction)
-		if err != nil {
-			return &CreateSellTransactionResponse{Success: false, Msg: err.Error()}, err
-		}
+		return &models.CreateSellTransactionResponse{Success: false, Msg: err.Error()}, err
 	}
-	tx, err := solana.TransactionDeserialize(swapTxBytes)
-	if err != nil {
-		return &CreateSellTransactionResponse{Success: false, Msg: err.Error()}, err
+	if err := checkSwapSimulation(serializedSwap.SimulationError); err != nil {
+		return &models.CreateSellTransactionResponse{Success: false, Msg: err.Error()}, err
 	}
-	// Sign the transaction.
-	// (Assuming appropriate signing method exists.)
-	// tx.SignPartial(keypair)
 
-	// Send transaction.
-	rawTx, err := tx.Serialize()
+	// Deserialize, sign and simulate the transaction.
+	tx, err := decodeSwapTransaction(serializedSwap.SwapTransaction)
 	if err != nil {
-		return &CreateSellTransactionResponse{Success: false, Msg: err.Error()}, err
+		return &models.CreateSellTransactionResponse{Success: false, Msg: err.Error()}, err
 	}
-	txid, err := rpcClient.SendRawTransaction(ctx, rawTx)
-	if err != nil {
-		return &CreateSellTransactionResponse{Success: false, Msg: err.Error()}, err
+	if err := signTransaction(tx, wallet); err != nil {
+		return &models.CreateSellTransactionResponse{Success: false, Msg: err.Error()}, err
 	}
-	// Confirm transaction.
-	recent, err := rpcClient.GetLatestBlockhash(ctx)
-	if err != nil {
-		return &CreateSellTransactionResponse{Success: false, Msg: err.Error()}, err
+	if err := simulateTransaction(ctx, rpcClient, tx, tokenMint, "sell"); err != nil {
+		return &models.CreateSellTransactionResponse{Success: false, Msg: err.Error()}, err
 	}
-	_, err = rpcClient.ConfirmTransaction(ctx, txid)
+
+	// Send transaction.
+	txid, err := sendTransaction(ctx, rpcClient, tx)
 	if err != nil {
-		return &CreateSellTransactionResponse{Success: false, Msg: "Transaction confirmation failed"}, err
+		return &models.CreateSellTransactionResponse{Success: false, Msg: err.Error()}, err
+	}
+
+	// Confirm transaction before its blockhash expires.
+	if err := confirmTransaction(ctx, rpcClient, txid, uint64(serializedSwap.LastValidBlockHeight)); err != nil {
+		var expired *BlockhashExpiredError
+		if errors.As(err, &expired) {
+			return &models.CreateSellTransactionResponse{Success: false, Msg: err.Error(), Tx: txid.String()}, err
+		}
+		return &models.CreateSellTransactionResponse{Success: false, Msg: "Transaction confirmation failed", Tx: txid.String()}, err
 	}
 	// Remove holding.
 	_ = db.RemoveHolding(tokenMint)
-	return &CreateSellTransactionResponse{
+	return &models.CreateSellTransactionResponse{
 		Success: true,
 		Msg:     "",
-		Tx:      txid,
+		Tx:      txid.String(),
 	}, nil
 }
 
-// ---------- Helper Functions ----------
+// ---------- Function: TokenBalance ----------
 
-func min(a, b float64) float64 {
-	if a < b {
-		return a
+// TokenBalance returns the wallet's raw balance (in the token's smallest unit)
+// of tokenMint across all of its token accounts. In simulation mode it returns
+// the balance of the open paper holdings instead.
+func (c *Client) TokenBalance(ctx context.Context, tokenMint string) (uint64, error) {
+	if config.Get().RugCheck.SimulationMode {
+		return c.paperBalance(ctx, tokenMint)
+	}
+	wallet, err := c.requireWallet()
+	if err != nil {
+		return 0, err
 	}
-	return b
+	return c.tokenBalance(ctx, wallet.PublicKey(), tokenMint)
 }
 
-func pow(a, b float64) float64 {
-	return math.Pow(a, b)
+func (c *Client) tokenBalance(ctx context.Context, owner solana.PublicKey, tokenMint string) (uint64, error) {
+	parsedMint, err := solana.PublicKeyFromBase58(tokenMint)
+	if err != nil {
+		return 0, err
+	}
+	tokenAccounts, err := c.rpcClient.GetTokenAccountsByOwner(ctx, owner, &rpc.GetTokenAccountsConfig{
+		Mint: &parsedMint,
+	}, &rpc.GetTokenAccountsOpts{
+		Encoding: solana.EncodingJSONParsed,
+	})
+	if err != nil {
+		return 0, err
+	}
+	totalBalance := uint64(0)
+	for _, acc := range tokenAccounts.Value {
+		var parsed struct {
+			Parsed struct {
+				Info struct {
+					TokenAmount struct {
+						Amount string `json:"amount"`
+					} `json:"tokenAmount"`
+				} `json:"info"`
+			} `json:"parsed"`
+		}
+		if err := json.Unmarshal(acc.Account.Data.GetRawJSON(), &parsed); err != nil {
+			return 0, err
+		}
+		balance, _ := strconv.ParseUint(parsed.Parsed.Info.TokenAmount.Amount, 10, 64)
+		totalBalance += balance
+	}
+	return totalBalance, nil
 }
 
-func decodeBase64(s string) ([]byte, error) {
-	return ioutil.ReadAll(base64.NewDecoder(base64.StdEncoding, strings.NewReader(s)))
-}
+// ---------- Helper Functions ----------
 
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
@@ -697,7 +654,7 @@ func contains(slice []string, item string) bool {
 	return false
 }
 
-func containsInsider(holders []Holder) bool {
+func containsInsider(holders []models.Holder) bool {
 	for _, h := range holders {
 		if h.Insider {
 			return true
@@ -706,7 +663,7 @@ func containsInsider(holders []Holder) bool {
 	return false
 }
 
-func anyHolderExceeds(holders []Holder, maxPct int) bool {
+func anyHolderExceeds(holders []models.Holder, maxPct int) bool {
 	for _, h := range holders {
 		if h.Pct > float64(maxPct) {
 			return true
@@ -715,7 +672,7 @@ func anyHolderExceeds(holders []Holder, maxPct int) bool {
 	return false
 }
 
-func anyRiskInLegacy(risks []Risk, legacy []string) bool {
+func anyRiskInLegacy(risks []models.Risk, legacy []string) bool {
 	for _, risk := range risks {
 		if contains(legacy, risk.Name) {
 			return true